@@ -0,0 +1,49 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package action
+
+import (
+	"math"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ux"
+	"github.com/richardwilkes/ux/border"
+	"github.com/richardwilkes/ux/draw"
+	"github.com/richardwilkes/ux/layout/align"
+	"github.com/richardwilkes/ux/layout/flex"
+	"github.com/richardwilkes/ux/widget/label"
+)
+
+// CellHeight returns the height of the cells created by NewCell.
+func CellHeight() float64 {
+	return math.Ceil(draw.ViewsFont.Height()) + 4
+}
+
+// NewCell creates a cell for a list of actions, showing the title of the
+// action followed by the shortcut text. 'shortcutInk' may be nil, in which
+// case the shortcut is drawn with the same ink as the title.
+func NewCell(a Action, shortcut string, shortcutInk draw.Ink, selected bool) *ux.Panel {
+	cell := ux.NewPanel()
+	cell.SetBorder(border.NewEmpty(geom.Insets{Top: 2, Left: 4, Bottom: 2, Right: 4}))
+	flex.New().Columns(2).HSpacing(10).Apply(cell)
+	title := label.New().SetText(a.Title()).SetFont(draw.ViewsFont)
+	flex.NewData().HAlign(align.Fill).HGrab(true).Apply(title)
+	cell.AddChild(title.AsPanel())
+	hotKey := label.New().SetText(shortcut).SetFont(draw.ViewsFont)
+	if shortcutInk != nil {
+		hotKey.SetInk(shortcutInk)
+	}
+	cell.AddChild(hotKey.AsPanel())
+	if selected {
+		title.SetInk(draw.AlternateSelectedControlTextColor)
+		hotKey.SetInk(draw.AlternateSelectedControlTextColor)
+	}
+	return cell
+}
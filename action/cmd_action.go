@@ -54,7 +54,8 @@ var (
 )
 
 // CmdAction provides a standardized way to issue commands to focused UI
-// elements.
+// elements. The command is sent to the first panel, starting with the focus
+// and moving up through its ancestors, that can perform it.
 type CmdAction struct {
 	ActionID        int
 	ActionTitle     string
@@ -88,7 +89,7 @@ func (a *CmdAction) HotKeyModifiers() keys.Modifiers {
 func (a *CmdAction) Enabled(source interface{}) bool {
 	if wnd := ux.WindowWithFocus(); wnd != nil {
 		focus := wnd.Focus()
-		return focus != nil && focus.CanPerformCmdCallback != nil && focus.CanPerformCmdCallback(source, a.ActionID)
+		return focus != nil && focus.CanPerformCmd(source, a.ActionID)
 	}
 	return false
 }
@@ -96,8 +97,8 @@ func (a *CmdAction) Enabled(source interface{}) bool {
 // Execute implements action.Action.
 func (a *CmdAction) Execute(source interface{}) {
 	if wnd := ux.WindowWithFocus(); wnd != nil {
-		if focus := wnd.Focus(); focus != nil && focus.PerformCmdCallback != nil {
-			focus.PerformCmdCallback(source, a.ActionID)
+		if focus := wnd.Focus(); focus != nil {
+			focus.PerformCmd(source, a.ActionID)
 		}
 	}
 }
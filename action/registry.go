@@ -0,0 +1,83 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package action
+
+var registry []Action
+
+func init() {
+	Register(Cut, Copy, Paste, Delete, SelectAll)
+}
+
+// Register adds actions to the registry. An action replaces any previously
+// registered action with the same ID.
+func Register(actions ...Action) {
+	for _, one := range actions {
+		if i := indexOf(one.ID()); i >= 0 {
+			registry[i] = one
+		} else {
+			registry = append(registry, one)
+		}
+	}
+}
+
+// Unregister removes the action with the ID from the registry.
+func Unregister(id int) {
+	if i := indexOf(id); i >= 0 {
+		copy(registry[i:], registry[i+1:])
+		registry[len(registry)-1] = nil
+		registry = registry[:len(registry)-1]
+	}
+}
+
+// Lookup returns the registered action with the ID, or nil.
+func Lookup(id int) Action {
+	if i := indexOf(id); i >= 0 {
+		return registry[i]
+	}
+	return nil
+}
+
+// All returns the registered actions, in the order they were registered.
+func All() []Action {
+	list := make([]Action, len(registry))
+	copy(list, registry)
+	return list
+}
+
+// AllEnabled returns the registered actions that are currently enabled for
+// the source, in the order they were registered.
+func AllEnabled(source interface{}) []Action {
+	var list []Action
+	for _, one := range registry {
+		if one.Enabled(source) {
+			list = append(list, one)
+		}
+	}
+	return list
+}
+
+// Perform executes the action if it is enabled for the source. Returns true
+// if it was executed. Menu items created from actions use this same logic.
+func Perform(a Action, source interface{}) bool {
+	if a.Enabled(source) {
+		a.Execute(source)
+		return true
+	}
+	return false
+}
+
+func indexOf(id int) int {
+	for i, one := range registry {
+		if one.ID() == id {
+			return i
+		}
+	}
+	return -1
+}
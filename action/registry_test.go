@@ -0,0 +1,61 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package action_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/ux/action"
+	"github.com/richardwilkes/ux/ids"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry(t *testing.T) {
+	assert.Equal(t, action.Cut, action.Lookup(ids.CutItemID))
+	count := len(action.All())
+	one := &action.CmdAction{ActionID: ids.UserBaseID, ActionTitle: "One"}
+	action.Register(one)
+	assert.Len(t, action.All(), count+1)
+	assert.Equal(t, one, action.Lookup(ids.UserBaseID))
+	replacement := &action.CmdAction{ActionID: ids.UserBaseID, ActionTitle: "Replacement"}
+	action.Register(replacement)
+	assert.Len(t, action.All(), count+1)
+	assert.Equal(t, replacement, action.Lookup(ids.UserBaseID))
+	action.Unregister(ids.UserBaseID)
+	assert.Len(t, action.All(), count)
+	assert.Nil(t, action.Lookup(ids.UserBaseID))
+}
+
+func TestSearch(t *testing.T) {
+	list := []action.Action{
+		&action.CmdAction{ActionID: 1, ActionTitle: "Paste"},
+		&action.CmdAction{ActionID: 2, ActionTitle: "Select All"},
+		&action.CmdAction{ActionID: 3, ActionTitle: "Save As…"},
+		&action.CmdAction{ActionID: 4, ActionTitle: "Save"},
+	}
+	titles := func(matches []*action.Match) []string {
+		result := make([]string, len(matches))
+		for i, m := range matches {
+			result[i] = m.Action.Title()
+		}
+		return result
+	}
+	assert.Equal(t, []string{"Paste", "Save", "Save As…", "Select All"}, titles(action.Search("", list)))
+	assert.Equal(t, []string{"Save", "Save As…"}, titles(action.Search("sav", list)))
+	assert.Equal(t, []string{"Save", "Save As…", "Select All"}, titles(action.Search("sa", list)))
+	assert.Equal(t, []string{"Select All"}, titles(action.Search("sall", list)))
+	assert.Empty(t, action.Search("xyz", list))
+
+	_, positions, ok := action.FuzzyMatch("sa", "Select All")
+	assert.True(t, ok)
+	assert.Equal(t, []int{0, 7}, positions)
+	_, _, ok = action.FuzzyMatch("las", "Select All")
+	assert.False(t, ok)
+}
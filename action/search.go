@@ -0,0 +1,154 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package action
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Scoring for fuzzy matches.
+const (
+	matchScore       = 16
+	wordStartBonus   = 24
+	consecutiveBonus = 24
+	gapPenalty       = 1
+	maxGapPenalty    = 8
+)
+
+// Match holds an action found by Search.
+type Match struct {
+	Action Action
+	// Positions holds the indexes of the runes within the action's title
+	// that matched the pattern.
+	Positions []int
+	Score     int
+}
+
+// Search returns the actions whose titles fuzzy match the pattern, best
+// matches first. Each rune of the pattern must appear in the title, in
+// order, ignoring case. Matches at the start of words and runs of
+// consecutive matches score higher. An empty pattern matches every action,
+// in which case they are sorted by title.
+func Search(pattern string, actions []Action) []*Match {
+	var matches []*Match
+	for _, one := range actions {
+		if score, positions, ok := FuzzyMatch(pattern, one.Title()); ok {
+			matches = append(matches, &Match{
+				Action:    one,
+				Positions: positions,
+				Score:     score,
+			})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		ti := matches[i].Action.Title()
+		tj := matches[j].Action.Title()
+		if len(ti) != len(tj) && pattern != "" {
+			return len(ti) < len(tj)
+		}
+		return strings.ToLower(ti) < strings.ToLower(tj)
+	})
+	return matches
+}
+
+// FuzzyMatch determines whether each rune of the pattern appears in the text,
+// in order, ignoring case. If so, the best score found is returned along with
+// the indexes of the runes within the text that were matched. Spaces in the
+// pattern are ignored.
+func FuzzyMatch(pattern, text string) (score int, positions []int, ok bool) {
+	var pat []rune
+	for _, ch := range pattern {
+		if !unicode.IsSpace(ch) {
+			pat = append(pat, unicode.ToLower(ch))
+		}
+	}
+	if len(pat) == 0 {
+		return 0, nil, true
+	}
+	txt := []rune(text)
+	lower := make([]rune, len(txt))
+	for i, ch := range txt {
+		lower[i] = unicode.ToLower(ch)
+	}
+	// best[i][j] holds the best score for matching pat[:i+1] with pat[i]
+	// matched at txt[j], or -1 if that isn't possible. from[i][j] holds the
+	// position pat[i-1] was matched at for that score.
+	best := make([][]int, len(pat))
+	from := make([][]int, len(pat))
+	for i := range pat {
+		best[i] = make([]int, len(txt))
+		from[i] = make([]int, len(txt))
+		for j := range txt {
+			best[i][j] = -1
+			if lower[j] != pat[i] {
+				continue
+			}
+			bonus := matchScore
+			if isWordStart(txt, j) {
+				bonus += wordStartBonus
+			}
+			if i == 0 {
+				best[i][j] = bonus
+				continue
+			}
+			for k := i - 1; k < j; k++ {
+				prev := best[i-1][k]
+				if prev < 0 {
+					continue
+				}
+				if k == j-1 {
+					prev += consecutiveBonus
+				} else {
+					penalty := (j - k - 1) * gapPenalty
+					if penalty > maxGapPenalty {
+						penalty = maxGapPenalty
+					}
+					prev -= penalty
+				}
+				if prev+bonus > best[i][j] {
+					best[i][j] = prev + bonus
+					from[i][j] = k
+				}
+			}
+		}
+	}
+	last := len(pat) - 1
+	end := -1
+	for j := range txt {
+		if best[last][j] >= 0 && (end < 0 || best[last][j] > best[last][end]) {
+			end = j
+		}
+	}
+	if end < 0 {
+		return 0, nil, false
+	}
+	positions = make([]int, len(pat))
+	for i, j := last, end; i >= 0; i-- {
+		positions[i] = j
+		j = from[i][j]
+	}
+	return best[last][end], positions, true
+}
+
+func isWordStart(txt []rune, i int) bool {
+	if i == 0 {
+		return true
+	}
+	prev := txt[i-1]
+	if !unicode.IsLetter(prev) && !unicode.IsDigit(prev) {
+		return true
+	}
+	return unicode.IsLower(prev) && unicode.IsUpper(txt[i])
+}
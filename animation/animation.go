@@ -0,0 +1,148 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+// Package animation provides property animators, timelines for combining
+// them and a player that runs them on the ux frame clock.
+package animation
+
+import (
+	"time"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ux/draw"
+	"github.com/richardwilkes/ux/ease"
+)
+
+// Animation defines the methods required of all animations.
+type Animation interface {
+	// Duration returns the total amount of time the animation takes.
+	Duration() time.Duration
+	// Seek updates the animation to reflect its state at the elapsed time,
+	// which will be in the range 0 to Duration(). While an animation is
+	// running, the elapsed time passed in never decreases; a smaller value
+	// indicates the animation has been restarted.
+	Seek(elapsed time.Duration)
+}
+
+// Tween animates a value by passing the eased progress of the animation, in
+// the range 0 to 1, to a function that applies it.
+type Tween struct {
+	duration time.Duration
+	easing   ease.Func
+	apply    func(progress float64)
+}
+
+// NewTween creates a new Tween. The easing defaults to ease.InOutQuad.
+func NewTween(duration time.Duration, apply func(progress float64)) *Tween {
+	if duration < 0 {
+		duration = 0
+	}
+	return &Tween{
+		duration: duration,
+		easing:   ease.InOutQuad,
+		apply:    apply,
+	}
+}
+
+// Float creates a new Tween that animates a float64 value.
+func Float(from, to float64, duration time.Duration, setter func(value float64)) *Tween {
+	return NewTween(duration, func(progress float64) {
+		setter(lerp(from, to, progress))
+	})
+}
+
+// Point creates a new Tween that animates a geom.Point value.
+func Point(from, to geom.Point, duration time.Duration, setter func(value geom.Point)) *Tween {
+	return NewTween(duration, func(progress float64) {
+		setter(geom.Point{
+			X: lerp(from.X, to.X, progress),
+			Y: lerp(from.Y, to.Y, progress),
+		})
+	})
+}
+
+// Rect creates a new Tween that animates a geom.Rect value.
+func Rect(from, to geom.Rect, duration time.Duration, setter func(value geom.Rect)) *Tween {
+	return NewTween(duration, func(progress float64) {
+		setter(geom.Rect{
+			Point: geom.Point{
+				X: lerp(from.X, to.X, progress),
+				Y: lerp(from.Y, to.Y, progress),
+			},
+			Size: geom.Size{
+				Width:  lerp(from.Width, to.Width, progress),
+				Height: lerp(from.Height, to.Height, progress),
+			},
+		})
+	})
+}
+
+// Color creates a new Tween that animates a draw.Color value. Each of the
+// color's channels, including alpha, is interpolated independently.
+func Color(from, to draw.Color, duration time.Duration, setter func(value draw.Color)) *Tween {
+	return NewTween(duration, func(progress float64) {
+		setter(draw.ARGBfloat(lerp(from.AlphaIntensity(), to.AlphaIntensity(), progress),
+			lerp(from.RedIntensity(), to.RedIntensity(), progress),
+			lerp(from.GreenIntensity(), to.GreenIntensity(), progress),
+			lerp(from.BlueIntensity(), to.BlueIntensity(), progress)))
+	})
+}
+
+// Easing sets the easing function to use. Passing in nil results in linear
+// progress.
+func (t *Tween) Easing(easing ease.Func) *Tween {
+	if easing == nil {
+		easing = ease.Linear
+	}
+	t.easing = easing
+	return t
+}
+
+// Duration implements Animation.
+func (t *Tween) Duration() time.Duration {
+	return t.duration
+}
+
+// Seek implements Animation.
+func (t *Tween) Seek(elapsed time.Duration) {
+	progress := 1.0
+	if elapsed < t.duration {
+		progress = t.easing(float64(elapsed) / float64(t.duration))
+	}
+	t.apply(progress)
+}
+
+// Delay creates a new Animation that does nothing for the duration. Useful
+// for inserting pauses into a sequence.
+func Delay(duration time.Duration) Animation {
+	return NewTween(duration, func(float64) {})
+}
+
+// Call creates a new Animation that takes no time and calls the function
+// when reached. Useful for performing an action at a specific point in a
+// sequence.
+func Call(f func()) Animation {
+	return &call{f: f}
+}
+
+type call struct {
+	f func()
+}
+
+func (c *call) Duration() time.Duration {
+	return 0
+}
+
+func (c *call) Seek(elapsed time.Duration) {
+	c.f()
+}
+
+func lerp(from, to, progress float64) float64 {
+	return from + (to-from)*progress
+}
@@ -0,0 +1,88 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package animation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ux/animation"
+	"github.com/richardwilkes/ux/draw"
+	"github.com/richardwilkes/ux/ease"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTween(t *testing.T) {
+	var value float64
+	tween := animation.Float(10, 20, time.Second, func(v float64) { value = v }).Easing(ease.Linear)
+	tween.Seek(0)
+	assert.Equal(t, 10.0, value)
+	tween.Seek(time.Second / 2)
+	assert.Equal(t, 15.0, value)
+	tween.Seek(time.Second)
+	assert.Equal(t, 20.0, value)
+
+	var pt geom.Point
+	animation.Point(geom.Point{}, geom.Point{X: 10, Y: -10}, time.Second, func(v geom.Point) { pt = v }).Easing(nil).Seek(time.Second / 4)
+	assert.Equal(t, geom.Point{X: 2.5, Y: -2.5}, pt)
+
+	var c draw.Color
+	animation.Color(draw.Black, draw.White, time.Second, func(v draw.Color) { c = v }).Seek(time.Second)
+	assert.Equal(t, draw.White, c)
+}
+
+func TestSequence(t *testing.T) {
+	var a, b float64
+	var calls int
+	seq := animation.Sequence(
+		animation.Float(0, 1, time.Second, func(v float64) { a = v }).Easing(ease.Linear),
+		animation.Call(func() { calls++ }),
+		animation.Delay(time.Second),
+		animation.Float(0, 1, time.Second, func(v float64) { b = v }).Easing(ease.Linear),
+	)
+	assert.Equal(t, 3*time.Second, seq.Duration())
+	seq.Seek(time.Second / 2)
+	assert.Equal(t, 0.5, a)
+	assert.Equal(t, 0, calls)
+	seq.Seek(1500 * time.Millisecond)
+	assert.Equal(t, 1.0, a)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, 0.0, b)
+	seq.Seek(2500 * time.Millisecond)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, 0.5, b)
+	seq.Seek(3 * time.Second)
+	assert.Equal(t, 1.0, b)
+
+	// Seeking again once complete shouldn't run anything again.
+	seq.Seek(3 * time.Second)
+	assert.Equal(t, 1, calls)
+
+	// Restarting should run everything again.
+	seq.Seek(0)
+	assert.Equal(t, 0.0, a)
+	seq.Seek(3 * time.Second)
+	assert.Equal(t, 2, calls)
+}
+
+func TestParallel(t *testing.T) {
+	var a, b float64
+	par := animation.Parallel(
+		animation.Float(0, 1, time.Second, func(v float64) { a = v }).Easing(ease.Linear),
+		animation.Float(0, 1, 2*time.Second, func(v float64) { b = v }).Easing(ease.Linear),
+	)
+	assert.Equal(t, 2*time.Second, par.Duration())
+	par.Seek(time.Second)
+	assert.Equal(t, 1.0, a)
+	assert.Equal(t, 0.5, b)
+	par.Seek(2 * time.Second)
+	assert.Equal(t, 1.0, b)
+}
@@ -0,0 +1,91 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package animation
+
+import (
+	"time"
+
+	"github.com/richardwilkes/ux"
+)
+
+// Player runs an animation.
+type Player struct {
+	animation Animation
+	start     time.Time
+	done      func(completed bool)
+	running   bool
+}
+
+// Start an animation. The animation is immediately brought to its starting
+// state and is then updated at each tick of the ux frame clock. If done is
+// not nil, it will be called once the animation finishes, with completed set
+// to false if the animation was cancelled. This should only be called from
+// the UI thread.
+func Start(animation Animation, done func(completed bool)) *Player {
+	p := &Player{
+		animation: animation,
+		start:     time.Now(),
+		done:      done,
+		running:   true,
+	}
+	if p.step(p.start) {
+		ux.AddFrameCallback(p.tick)
+	}
+	return p
+}
+
+// Running returns true if the animation is still in progress.
+func (p *Player) Running() bool {
+	return p.running
+}
+
+// Cancel stops the animation, leaving any values it has animated as they
+// currently are.
+func (p *Player) Cancel() {
+	if p.running {
+		p.running = false
+		if p.done != nil {
+			p.done(false)
+		}
+	}
+}
+
+// Finish stops the animation, bringing it to its final state.
+func (p *Player) Finish() {
+	if p.running {
+		p.animation.Seek(p.animation.Duration())
+		p.complete()
+	}
+}
+
+// step advances the animation, returning true if it is still running.
+func (p *Player) step(now time.Time) bool {
+	elapsed := now.Sub(p.start)
+	if d := p.animation.Duration(); elapsed >= d {
+		p.animation.Seek(d)
+		p.complete()
+		return false
+	}
+	p.animation.Seek(elapsed)
+	return true
+}
+
+func (p *Player) complete() {
+	p.running = false
+	if p.done != nil {
+		p.done(true)
+	}
+}
+
+// tick is called by the frame clock, returning true while the animation is
+// still running.
+func (p *Player) tick(now time.Time) bool {
+	return p.running && p.step(now)
+}
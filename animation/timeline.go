@@ -0,0 +1,83 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package animation
+
+import "time"
+
+// Timeline combines other animations into a single animation.
+type Timeline struct {
+	animations []Animation
+	offsets    []time.Duration
+	done       []bool
+	duration   time.Duration
+	last       time.Duration
+}
+
+// Sequence creates a new Timeline that runs the animations one after the
+// other.
+func Sequence(animations ...Animation) *Timeline {
+	t := newTimeline(animations)
+	for i, one := range animations {
+		t.offsets[i] = t.duration
+		t.duration += one.Duration()
+	}
+	return t
+}
+
+// Parallel creates a new Timeline that runs the animations at the same
+// time. The Timeline completes when the longest of them completes.
+func Parallel(animations ...Animation) *Timeline {
+	t := newTimeline(animations)
+	for _, one := range animations {
+		if d := one.Duration(); d > t.duration {
+			t.duration = d
+		}
+	}
+	return t
+}
+
+func newTimeline(animations []Animation) *Timeline {
+	return &Timeline{
+		animations: animations,
+		offsets:    make([]time.Duration, len(animations)),
+		done:       make([]bool, len(animations)),
+	}
+}
+
+// Duration implements Animation.
+func (t *Timeline) Duration() time.Duration {
+	return t.duration
+}
+
+// Seek implements Animation. Each animation within the timeline is brought
+// up to date once it has been reached and is no longer updated once it has
+// completed.
+func (t *Timeline) Seek(elapsed time.Duration) {
+	if elapsed < t.last {
+		for i := range t.done {
+			t.done[i] = false
+		}
+	}
+	t.last = elapsed
+	for i, one := range t.animations {
+		if t.done[i] {
+			continue
+		}
+		local := elapsed - t.offsets[i]
+		if local < 0 {
+			continue
+		}
+		if d := one.Duration(); local >= d {
+			local = d
+			t.done[i] = true
+		}
+		one.Seek(local)
+	}
+}
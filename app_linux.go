@@ -10,6 +10,7 @@
 package ux
 
 import (
+	"github.com/BurntSushi/xgbutil/keybind"
 	"github.com/BurntSushi/xgbutil/mousebind"
 	"github.com/BurntSushi/xgbutil/xevent"
 	"github.com/richardwilkes/toolbox/atexit"
@@ -21,6 +22,7 @@ import (
 var awaitingQuitDecision bool
 
 func osStart() {
+	keybind.Initialize(globals.X11)
 	mousebind.Initialize(globals.X11)
 	draw.UpdateSystemColors()
 	draw.Initialize()
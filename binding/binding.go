@@ -0,0 +1,157 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package binding
+
+import (
+	"github.com/richardwilkes/ux"
+)
+
+// Converter converts values between the form held by a Value and the form
+// used by a widget.
+type Converter interface {
+	// ToWidget converts a model value into a widget value.
+	ToWidget(value interface{}) interface{}
+	// FromWidget converts a widget value into a model value. An error should
+	// be returned if the widget value cannot be converted.
+	FromWidget(value interface{}) (interface{}, error)
+}
+
+// Validator checks a model value, returning an error if it is not
+// acceptable.
+type Validator func(value interface{}) error
+
+// Binding keeps a Value and a widget in sync. Changes made by the user are
+// converted and validated before being stored in the Value, while changes
+// to the Value are converted and shown by the widget. The binding
+// disconnects itself when the widget leaves its window.
+type Binding struct {
+	value         *Value
+	get           func() interface{}
+	set           func(value interface{})
+	invalidated   func()
+	converter     Converter
+	validator     Validator
+	err           error
+	cancelObserve func()
+	cancelHook    func()
+	updating      bool
+}
+
+// newBinding creates a new binding. The caller is responsible for hooking
+// the widget's callbacks up to widgetChanged() and then calling
+// modelChanged() to perform the initial sync.
+func newBinding(panel *ux.Panel, value *Value, get func() interface{}, set func(value interface{})) *Binding {
+	b := &Binding{
+		value: value,
+		get:   get,
+		set:   set,
+	}
+	b.cancelObserve = value.Observe(b.modelChanged)
+	b.cancelHook = panel.AddWindowExitHook(b.Disconnect)
+	return b
+}
+
+// Converter sets the converter to use. Pass in nil to pass values through
+// unchanged.
+func (b *Binding) Converter(converter Converter) *Binding {
+	b.converter = converter
+	if b.Connected() {
+		b.modelChanged(b.value.Get())
+	}
+	return b
+}
+
+// Validator sets the validator to use. Pass in nil to accept all values.
+func (b *Binding) Validator(validator Validator) *Binding {
+	b.validator = validator
+	if b.Connected() {
+		b.modelChanged(b.value.Get())
+	}
+	return b
+}
+
+// Value returns the Value the binding was created with.
+func (b *Binding) Value() *Value {
+	return b.value
+}
+
+// Err returns the error produced by converting or validating the widget's
+// current value, if any. While an error is present, the Value is not
+// updated.
+func (b *Binding) Err() error {
+	return b.err
+}
+
+// Connected returns true if the binding is still keeping the Value and the
+// widget in sync.
+func (b *Binding) Connected() bool {
+	return b.cancelObserve != nil
+}
+
+// Disconnect the binding. The Value and the widget retain their current
+// contents, but no longer affect each other.
+func (b *Binding) Disconnect() {
+	if b.cancelObserve != nil {
+		b.cancelObserve()
+		b.cancelObserve = nil
+		b.cancelHook()
+		b.cancelHook = nil
+	}
+}
+
+func (b *Binding) modelChanged(value interface{}) {
+	if b.updating {
+		return
+	}
+	b.updating = true
+	if b.converter != nil {
+		value = b.converter.ToWidget(value)
+	}
+	b.set(value)
+	b.updating = false
+	_, b.err = b.check()
+	b.notifyInvalidated()
+}
+
+func (b *Binding) widgetChanged() {
+	if b.updating || !b.Connected() {
+		return
+	}
+	var value interface{}
+	if value, b.err = b.check(); b.err == nil {
+		b.updating = true
+		b.value.Set(value)
+		b.updating = false
+	}
+	b.notifyInvalidated()
+}
+
+// check converts and validates the widget's current value.
+func (b *Binding) check() (interface{}, error) {
+	value := b.get()
+	if b.converter != nil {
+		var err error
+		if value, err = b.converter.FromWidget(value); err != nil {
+			return nil, err
+		}
+	}
+	if b.validator != nil {
+		if err := b.validator(value); err != nil {
+			return nil, err
+		}
+	}
+	return value, nil
+}
+
+func (b *Binding) notifyInvalidated() {
+	if b.invalidated != nil {
+		b.invalidated()
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package binding_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/ux/binding"
+	"github.com/richardwilkes/ux/widget/checkbox/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValue(t *testing.T) {
+	v := binding.NewValue(1)
+	var seen []interface{}
+	cancel := v.Observe(func(value interface{}) { seen = append(seen, value) })
+	v.Set(1)
+	v.Set(2)
+	v.Set([]int{1, 2})
+	v.Set([]int{1, 2})
+	cancel()
+	v.Set(3)
+	assert.Equal(t, []interface{}{2, []int{1, 2}}, seen)
+	assert.Equal(t, 3, v.Get())
+}
+
+func TestConverters(t *testing.T) {
+	assert.Equal(t, "12", binding.IntText.ToWidget(12))
+	v, err := binding.IntText.FromWidget(" 42 ")
+	assert.NoError(t, err)
+	assert.Equal(t, 42, v)
+	_, err = binding.IntText.FromWidget("abc")
+	assert.Error(t, err)
+
+	assert.Equal(t, "1.5", binding.Float64Text.ToWidget(1.5))
+	v, err = binding.Float64Text.FromWidget("2.25")
+	assert.NoError(t, err)
+	assert.Equal(t, 2.25, v)
+
+	assert.Equal(t, state.On, binding.BoolState.ToWidget(true))
+	assert.Equal(t, state.Off, binding.BoolState.ToWidget(false))
+	v, err = binding.BoolState.FromWidget(state.On)
+	assert.NoError(t, err)
+	assert.Equal(t, true, v)
+	_, err = binding.BoolState.FromWidget(state.Mixed)
+	assert.Error(t, err)
+}
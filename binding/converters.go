@@ -0,0 +1,94 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package binding
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/ux/widget/checkbox/state"
+)
+
+// Standard converters.
+var (
+	// IntText converts between an int model value and a string widget value.
+	IntText Converter = &Funcs{
+		To: func(value interface{}) interface{} {
+			v, _ := value.(int)
+			return strconv.Itoa(v)
+		},
+		From: func(value interface{}) (interface{}, error) {
+			v, err := strconv.Atoi(strings.TrimSpace(value.(string)))
+			if err != nil {
+				return nil, errs.NewWithCause("not a whole number", err)
+			}
+			return v, nil
+		},
+	}
+	// Float64Text converts between a float64 model value and a string widget
+	// value.
+	Float64Text Converter = &Funcs{
+		To: func(value interface{}) interface{} {
+			v, _ := value.(float64)
+			return strconv.FormatFloat(v, 'g', -1, 64)
+		},
+		From: func(value interface{}) (interface{}, error) {
+			v, err := strconv.ParseFloat(strings.TrimSpace(value.(string)), 64)
+			if err != nil {
+				return nil, errs.NewWithCause("not a number", err)
+			}
+			return v, nil
+		},
+	}
+	// BoolState converts between a bool model value and a state.State widget
+	// value. A mixed state cannot be converted.
+	BoolState Converter = &Funcs{
+		To: func(value interface{}) interface{} {
+			if v, _ := value.(bool); v {
+				return state.On
+			}
+			return state.Off
+		},
+		From: func(value interface{}) (interface{}, error) {
+			switch value.(state.State) {
+			case state.On:
+				return true, nil
+			case state.Off:
+				return false, nil
+			default:
+				return nil, errs.New("mixed state has no boolean equivalent")
+			}
+		},
+	}
+)
+
+// Funcs provides a Converter that calls functions to do the conversions.
+// A nil function passes values through unchanged.
+type Funcs struct {
+	To   func(value interface{}) interface{}
+	From func(value interface{}) (interface{}, error)
+}
+
+// ToWidget implements Converter.
+func (f *Funcs) ToWidget(value interface{}) interface{} {
+	if f.To == nil {
+		return value
+	}
+	return f.To(value)
+}
+
+// FromWidget implements Converter.
+func (f *Funcs) FromWidget(value interface{}) (interface{}, error) {
+	if f.From == nil {
+		return value, nil
+	}
+	return f.From(value)
+}
@@ -0,0 +1,64 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+// Package binding provides observable values and two-way bindings between
+// them and widgets.
+package binding
+
+import "reflect"
+
+// Value holds a value and notifies its observers when it changes.
+type Value struct {
+	value     interface{}
+	observers []*observer
+}
+
+type observer struct {
+	f func(value interface{})
+}
+
+// NewValue creates a new Value.
+func NewValue(initial interface{}) *Value {
+	return &Value{value: initial}
+}
+
+// Get returns the current value.
+func (v *Value) Get() interface{} {
+	return v.value
+}
+
+// Set the value. Observers are notified only if the new value differs from
+// the current one.
+func (v *Value) Set(value interface{}) {
+	if !reflect.DeepEqual(v.value, value) {
+		v.value = value
+		observers := make([]*observer, len(v.observers))
+		copy(observers, v.observers)
+		for _, one := range observers {
+			one.f(value)
+		}
+	}
+}
+
+// Observe adds a function to be called with the new value whenever it
+// changes. Call the returned function to stop observing.
+func (v *Value) Observe(f func(value interface{})) (cancel func()) {
+	obs := &observer{f: f}
+	v.observers = append(v.observers, obs)
+	return func() {
+		for i, one := range v.observers {
+			if one == obs {
+				copy(v.observers[i:], v.observers[i+1:])
+				v.observers[len(v.observers)-1] = nil
+				v.observers = v.observers[:len(v.observers)-1]
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package binding
+
+import (
+	"reflect"
+
+	"github.com/richardwilkes/ux/widget/checkbox"
+	"github.com/richardwilkes/ux/widget/checkbox/state"
+	"github.com/richardwilkes/ux/widget/list"
+	"github.com/richardwilkes/ux/widget/popupmenu"
+	"github.com/richardwilkes/ux/widget/radiobutton"
+	"github.com/richardwilkes/ux/widget/textfield"
+)
+
+// Text binds a value to the text of a text field. The widget value is a
+// string. While the binding has an error, the field is marked invalid.
+func Text(field *textfield.TextField, value *Value) *Binding {
+	b := newBinding(field.AsPanel(), value, func() interface{} {
+		return field.Text()
+	}, func(v interface{}) {
+		text, _ := v.(string)
+		field.SetText(text)
+	})
+	b.invalidated = field.Validate
+	modified := field.ModifiedCallback
+	field.ModifiedCallback = func() {
+		b.widgetChanged()
+		if modified != nil {
+			modified()
+		}
+	}
+	validate := field.ValidateCallback
+	field.ValidateCallback = func() bool {
+		valid := validate == nil || validate()
+		return valid && (!b.Connected() || b.err == nil)
+	}
+	b.modelChanged(value.Get())
+	return b
+}
+
+// CheckBox binds a value to the state of a check box. The widget value is a
+// state.State. Use the BoolState converter to bind a bool value.
+func CheckBox(box *checkbox.CheckBox, value *Value) *Binding {
+	b := newBinding(box.AsPanel(), value, func() interface{} {
+		return box.State()
+	}, func(v interface{}) {
+		s, _ := v.(state.State)
+		box.SetState(s)
+	})
+	click := box.ClickCallback
+	box.ClickCallback = func() {
+		b.widgetChanged()
+		if click != nil {
+			click()
+		}
+	}
+	b.modelChanged(value.Get())
+	return b
+}
+
+// RadioButton binds a value to the selection of a radio button. The widget
+// value is the choice, which is stored in the value when the user selects
+// the radio button. The radio button is selected whenever the value matches
+// the choice. Bind each radio button in a group to the same value with a
+// different choice.
+func RadioButton(button *radiobutton.RadioButton, value *Value, choice interface{}) *Binding {
+	b := newBinding(button.AsPanel(), value, func() interface{} {
+		return choice
+	}, func(v interface{}) {
+		if reflect.DeepEqual(v, choice) {
+			button.SetSelected(true)
+		}
+	})
+	click := button.ClickCallback
+	button.ClickCallback = func() {
+		if button.Selected() {
+			b.widgetChanged()
+		}
+		if click != nil {
+			click()
+		}
+	}
+	b.modelChanged(value.Get())
+	return b
+}
+
+// PopupMenu binds a value to the selection of a popup menu. The widget value
+// is the selected item.
+func PopupMenu(popup *popupmenu.PopupMenu, value *Value) *Binding {
+	b := newBinding(popup.AsPanel(), value, popup.Selected, func(v interface{}) {
+		popup.Select(v)
+	})
+	selection := popup.SelectionCallback
+	popup.SelectionCallback = func() {
+		b.widgetChanged()
+		if selection != nil {
+			selection()
+		}
+	}
+	b.modelChanged(value.Get())
+	return b
+}
+
+// List binds a value to the selection of a list. The widget value is a
+// []int holding the selected indexes in ascending order.
+func List(l *list.List, value *Value) *Binding {
+	b := newBinding(l.AsPanel(), value, func() interface{} {
+		indexes := make([]int, 0, l.Selection.Count())
+		for i := l.Selection.FirstSet(); i != -1; i = l.Selection.NextSet(i + 1) {
+			indexes = append(indexes, i)
+		}
+		return indexes
+	}, func(v interface{}) {
+		indexes, _ := v.([]int)
+		l.Selection.Reset()
+		for _, i := range indexes {
+			l.Selection.Set(i)
+		}
+		l.MarkForRedraw()
+	})
+	selection := l.NewSelectionCallback
+	l.NewSelectionCallback = func() {
+		b.widgetChanged()
+		if selection != nil {
+			selection()
+		}
+	}
+	b.modelChanged(value.Get())
+	return b
+}
@@ -0,0 +1,99 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package clipboard
+
+import "github.com/richardwilkes/ux/clipboard/datatypes"
+
+// Backend defines the methods a clipboard implementation must provide.
+type Backend interface {
+	// Clear removes the clipboard contents.
+	Clear()
+	// ChangeCount returns a number that changes whenever the clipboard
+	// contents change.
+	ChangeCount() int
+	// Types returns the types of data currently on the clipboard.
+	Types() []datatypes.DataType
+	// Data returns the data for each item on the clipboard that has the
+	// type.
+	Data(dataType datatypes.DataType) [][]byte
+	// SetData replaces the clipboard contents.
+	SetData(data []map[datatypes.DataType][]byte)
+	// PrimaryText returns the text of the primary selection.
+	PrimaryText() string
+	// SetPrimaryText sets the text of the primary selection.
+	SetPrimaryText(text string)
+}
+
+var (
+	backend Backend = systemBackend{}
+	history *History
+)
+
+// SetBackend sets the backend used by the clipboard. This should be done at
+// startup, before the clipboard is first used. Passing nil restores the
+// system clipboard.
+func SetBackend(b Backend) {
+	if b == nil {
+		b = systemBackend{}
+	}
+	backend = b
+	clipboardLastChangeCount = -1
+	clipboardDataTypes = nil
+}
+
+// CurrentBackend returns the backend in use by the clipboard.
+func CurrentBackend() Backend {
+	return backend
+}
+
+// SetHistory sets the history that records data placed on the clipboard.
+// Pass nil to stop recording.
+func SetHistory(h *History) {
+	history = h
+}
+
+// CurrentHistory returns the history that records data placed on the
+// clipboard, or nil if there isn't one.
+func CurrentHistory() *History {
+	return history
+}
+
+// systemBackend provides access to the platform's clipboard.
+type systemBackend struct{}
+
+func (b systemBackend) Clear() {
+	osClear()
+}
+
+func (b systemBackend) ChangeCount() int {
+	return osChangeCount()
+}
+
+func (b systemBackend) Types() []datatypes.DataType {
+	clipboardDataTypes = nil
+	osLoadTypes()
+	return clipboardDataTypes
+}
+
+func (b systemBackend) Data(dataType datatypes.DataType) [][]byte {
+	return osGetData(dataType)
+}
+
+func (b systemBackend) SetData(data []map[datatypes.DataType][]byte) {
+	osSetData(data)
+}
+
+func (b systemBackend) PrimaryText() string {
+	return osPrimaryText()
+}
+
+func (b systemBackend) SetPrimaryText(text string) {
+	osSetPrimaryText(text)
+}
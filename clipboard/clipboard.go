@@ -20,35 +20,37 @@ var (
 func Clear() {
 	clipboardLastChangeCount = -1
 	clipboardDataTypes = nil
-	osClear()
+	backend.Clear()
 }
 
-// HasType returns true if the specified data type exists on the clipboard.
+// HasType returns true if the specified data type exists on the clipboard,
+// or can be provided from the data on the clipboard through conformance or
+// conversion.
 func HasType(dataType datatypes.DataType) bool {
-	for _, one := range Types() {
+	types := Types()
+	for _, one := range types {
 		if dataType == one || (dataType.UTI != "" && dataType.UTI == one.UTI) || (dataType.Mime != "" && dataType.Mime == one.Mime) {
 			return true
 		}
 	}
-	return false
+	return datatypes.CanProvide(dataType, types)
 }
 
 // Types returns the types of data currently on the clipboard.
 func Types() []datatypes.DataType {
-	changeCount := osChangeCount()
+	changeCount := backend.ChangeCount()
 	if changeCount != clipboardLastChangeCount {
 		clipboardLastChangeCount = changeCount
-		clipboardDataTypes = nil
-		osLoadTypes()
+		clipboardDataTypes = backend.Types()
 	}
 	return clipboardDataTypes
 }
 
 // GetFirstData returns the bytes for the first item associated with the
 // specified data type on the clipboard. An empty slice will be returned if no
-// such data type is present.
+// such data type is present. See GetData() for how the data is located.
 func GetFirstData(dataType datatypes.DataType) []byte {
-	data := osGetData(dataType)
+	data := GetData(dataType)
 	if len(data) > 0 {
 		return data[0]
 	}
@@ -56,25 +58,46 @@ func GetFirstData(dataType datatypes.DataType) []byte {
 }
 
 // GetData returns a slice holding slices of bytes associated with the
-// specified data type on the clipboard. An empty slice will be returned if no
-// such data type is present.
+// specified data type on the clipboard. If the data type isn't present, data
+// of a type that conforms to it or that can be converted to it is returned
+// instead. An empty slice will be returned if no such data is present.
 func GetData(dataType datatypes.DataType) [][]byte {
-	return osGetData(dataType)
+	if data := backend.Data(dataType); len(data) != 0 {
+		return data
+	}
+	return datatypes.Provide(dataType, Types(), backend.Data)
 }
 
-// SetDataWithType sets the data into the system clipboard.
+// SetDataWithType sets the data into the clipboard.
 func SetDataWithType(data []byte, dataType datatypes.DataType) {
-	osSetData([]map[datatypes.DataType][]byte{{dataType: data}})
+	SetData([]map[datatypes.DataType][]byte{{dataType: data}})
 }
 
-// SetDataWithMultipleTypes sets the data into the system clipboard.
+// SetDataWithMultipleTypes sets the data into the clipboard.
 func SetDataWithMultipleTypes(data map[datatypes.DataType][]byte) {
-	osSetData([]map[datatypes.DataType][]byte{data})
+	SetData([]map[datatypes.DataType][]byte{data})
 }
 
-// SetData sets the data into the system clipboard.
+// SetData sets the data into the clipboard. If a history has been set, the
+// data is also recorded there.
 func SetData(data []map[datatypes.DataType][]byte) {
-	osSetData(data)
+	if history != nil {
+		history.Add(data)
+	}
+	backend.SetData(data)
+}
+
+// SetPrimaryText sets the text of the primary selection, which holds the
+// text most recently selected by the user. Only X11 has a primary selection,
+// so elsewhere this does nothing.
+func SetPrimaryText(text string) {
+	backend.SetPrimaryText(text)
+}
+
+// PrimaryText returns the text of the primary selection. Only X11 has a
+// primary selection, so elsewhere this always returns an empty string.
+func PrimaryText() string {
+	return backend.PrimaryText()
 }
 
 // BytesToURL converts bytes into a URL. On most platforms, this is just a
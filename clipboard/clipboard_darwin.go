@@ -36,6 +36,13 @@ func osSetData(data []map[datatypes.DataType][]byte) {
 	SetDataToPasteboard(ns.PasteboardGeneral(), data)
 }
 
+func osSetPrimaryText(text string) {
+}
+
+func osPrimaryText() string {
+	return ""
+}
+
 func osBytesToURL(in []byte) string {
 	resolved := ns.URLWithString(string(in)).ResolveFilePath()
 	if resolved != "" {
@@ -9,27 +9,188 @@
 
 package clipboard
 
-import "github.com/richardwilkes/ux/clipboard/datatypes"
+import (
+	"time"
+
+	"github.com/BurntSushi/xgb/xproto"
+	"github.com/BurntSushi/xgbutil/xwindow"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/log/jot"
+	"github.com/richardwilkes/ux/clipboard/datatypes"
+	"github.com/richardwilkes/ux/globals"
+	"github.com/richardwilkes/ux/xsel"
+)
+
+// How long to wait for the owner of a selection to respond.
+const requestTimeout = 2 * time.Second
+
+var (
+	selectionWindow xproto.Window
+	clipboardData   []map[datatypes.DataType][]byte
+	primaryText     []byte
+)
+
+// window returns the hidden window used to own and request selections, or
+// xproto.WindowNone if one could not be created.
+func window() xproto.Window {
+	if selectionWindow == xproto.WindowNone && globals.X11 != nil {
+		w, err := xwindow.Generate(globals.X11)
+		if err != nil {
+			jot.Error(errs.Wrap(err))
+			return xproto.WindowNone
+		}
+		if err = w.CreateChecked(globals.X11.RootWin(), -1, -1, 1, 1, xproto.CwEventMask, xproto.EventMaskPropertyChange); err != nil {
+			jot.Error(errs.Wrap(err))
+			return xproto.WindowNone
+		}
+		xsel.Attach(w.Id)
+		selectionWindow = w.Id
+	}
+	return selectionWindow
+}
 
 func osClear() {
-	// RAW: Implement
+	if window() != xproto.WindowNone {
+		xsel.Disown(xsel.Atom("CLIPBOARD"))
+	}
+	clipboardData = nil
 }
 
 func osChangeCount() int {
-	return 0
+	if window() == xproto.WindowNone {
+		return 0
+	}
+	return xsel.ChangeCount(xsel.Atom("CLIPBOARD"))
 }
 
 func osLoadTypes() {
-	// RAW: Implement
+	wnd := window()
+	if wnd == xproto.WindowNone {
+		return
+	}
+	data, ok := xsel.RequestAndWait(wnd, xsel.Atom("CLIPBOARD"), xsel.Atom("TARGETS"), requestTimeout)
+	if !ok {
+		return
+	}
+	for _, atom := range xsel.Atoms(data) {
+		if dt, ok := xsel.DataTypeForAtom(atom); ok && !hasType(clipboardDataTypes, dt) {
+			clipboardDataTypes = append(clipboardDataTypes, dt)
+		}
+	}
+}
+
+func hasType(list []datatypes.DataType, dataType datatypes.DataType) bool {
+	for _, one := range list {
+		if one.UTI == dataType.UTI {
+			return true
+		}
+	}
+	return false
 }
 
 func osGetData(dataType datatypes.DataType) [][]byte {
-	// RAW: Implement
+	wnd := window()
+	if wnd == xproto.WindowNone {
+		return nil
+	}
+	selection := xsel.Atom("CLIPBOARD")
+	if xsel.Owns(selection) {
+		return dataFor(clipboardData, dataType)
+	}
+	for _, atom := range xsel.AtomsForDataType(dataType) {
+		if data, ok := xsel.RequestAndWait(wnd, selection, atom, requestTimeout); ok {
+			if dataType.UTI == datatypes.FileURL.UTI {
+				return xsel.SplitURIList(data)
+			}
+			return [][]byte{data}
+		}
+	}
 	return nil
 }
 
+func dataFor(items []map[datatypes.DataType][]byte, dataType datatypes.DataType) [][]byte {
+	var result [][]byte
+	for _, item := range items {
+		for dt, data := range item {
+			if dt.UTI == dataType.UTI {
+				result = append(result, data)
+				break
+			}
+		}
+	}
+	return result
+}
+
 func osSetData(data []map[datatypes.DataType][]byte) {
-	// RAW: Implement
+	wnd := window()
+	if wnd == xproto.WindowNone {
+		return
+	}
+	clipboardData = data
+	var targets []xproto.Atom
+	seen := make(map[xproto.Atom]bool)
+	for _, item := range data {
+		for dt := range item {
+			for _, atom := range xsel.AtomsForDataType(dt) {
+				if !seen[atom] {
+					seen[atom] = true
+					targets = append(targets, atom)
+				}
+			}
+		}
+	}
+	xsel.Own(wnd, xsel.Atom("CLIPBOARD"), targets, func(target xproto.Atom) ([]byte, bool) {
+		dt, ok := xsel.DataTypeForAtom(target)
+		if !ok {
+			return nil, false
+		}
+		list := dataFor(data, dt)
+		if len(list) == 0 {
+			return nil, false
+		}
+		if dt.UTI == datatypes.FileURL.UTI {
+			return xsel.JoinURIList(list), true
+		}
+		return list[0], true
+	}, func() {
+		clipboardData = nil
+	})
+}
+
+func osSetPrimaryText(text string) {
+	wnd := window()
+	if wnd == xproto.WindowNone {
+		return
+	}
+	primaryText = []byte(text)
+	selection := xsel.Atom("PRIMARY")
+	if xsel.Owns(selection) {
+		// The provider reads the current text, so there is no need to claim
+		// ownership again.
+		return
+	}
+	xsel.Own(wnd, selection, xsel.AtomsForDataType(datatypes.PlainText), func(target xproto.Atom) ([]byte, bool) {
+		return primaryText, true
+	}, func() {
+		primaryText = nil
+	})
+}
+
+func osPrimaryText() string {
+	wnd := window()
+	if wnd == xproto.WindowNone {
+		return ""
+	}
+	selection := xsel.Atom("PRIMARY")
+	if xsel.Owns(selection) {
+		return string(primaryText)
+	}
+	for _, atom := range xsel.AtomsForDataType(datatypes.PlainText) {
+		if data, ok := xsel.RequestAndWait(wnd, selection, atom, requestTimeout); ok {
+			return string(data)
+		}
+	}
+	return ""
 }
 
 func osBytesToURL(in []byte) string {
@@ -0,0 +1,61 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package clipboard_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/ux/clipboard"
+	"github.com/richardwilkes/ux/clipboard/datatypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryBackend(t *testing.T) {
+	clipboard.SetBackend(clipboard.NewMemoryBackend())
+	defer clipboard.SetBackend(nil)
+	assert.Empty(t, clipboard.Types())
+	clipboard.SetDataWithType([]byte("hello"), datatypes.PlainText)
+	assert.True(t, clipboard.HasType(datatypes.PlainText))
+	assert.False(t, clipboard.HasType(datatypes.PNG))
+	assert.Equal(t, []byte("hello"), clipboard.GetFirstData(datatypes.PlainText))
+	clipboard.SetData([]map[datatypes.DataType][]byte{
+		{datatypes.FileURL: []byte("file:///a")},
+		{datatypes.FileURL: []byte("file:///b")},
+	})
+	assert.False(t, clipboard.HasType(datatypes.PlainText))
+	assert.Equal(t, [][]byte{[]byte("file:///a"), []byte("file:///b")}, clipboard.GetData(datatypes.FileURL))
+	clipboard.Clear()
+	assert.Empty(t, clipboard.Types())
+	clipboard.SetPrimaryText("selected")
+	assert.Equal(t, "selected", clipboard.PrimaryText())
+}
+
+func TestHistory(t *testing.T) {
+	clipboard.SetBackend(clipboard.NewMemoryBackend())
+	defer clipboard.SetBackend(nil)
+	h := clipboard.NewHistory(2)
+	clipboard.SetHistory(h)
+	defer clipboard.SetHistory(nil)
+	clipboard.SetDataWithType([]byte("one"), datatypes.PlainText)
+	clipboard.SetDataWithType([]byte("two"), datatypes.PlainText)
+	clipboard.SetDataWithType([]byte("one"), datatypes.PlainText)
+	entries := h.Entries()
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "one", entries[0].Text())
+	assert.Equal(t, "two", entries[1].Text())
+	assert.False(t, entries[0].When.Before(entries[1].When))
+	clipboard.SetDataWithType([]byte("three"), datatypes.PlainText)
+	entries = h.Entries()
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "three", entries[0].Text())
+	assert.Equal(t, "one", entries[1].Text())
+	h.Clear()
+	assert.Equal(t, 0, h.Len())
+}
@@ -127,6 +127,13 @@ func osSetData(data []map[datatypes.DataType][]byte) {
 	}
 }
 
+func osSetPrimaryText(text string) {
+}
+
+func osPrimaryText() string {
+	return ""
+}
+
 func osBytesToURL(in []byte) string {
 	return string(in)
 }
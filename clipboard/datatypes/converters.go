@@ -0,0 +1,254 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package datatypes
+
+import (
+	"bytes"
+	"html"
+	"image"
+	_ "image/gif"  // Register the GIF decoder
+	_ "image/jpeg" // Register the JPEG decoder
+	"image/png"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/richardwilkes/toolbox/errs"
+	_ "golang.org/x/image/bmp"  // Register the BMP decoder
+	_ "golang.org/x/image/tiff" // Register the TIFF decoder
+)
+
+func registerStandardConverters() {
+	RegisterConverter(HTMLText, PlainText, HTMLToPlainText)
+	RegisterConverter(RTFText, PlainText, RTFToPlainText)
+	for _, one := range []DataType{TIFF, BMP, GIF, JPEG} {
+		RegisterConverter(one, PNG, ImageToPNG)
+	}
+}
+
+// windows1252 holds the characters that Windows-1252 places in the range
+// 0x80-0x9F, where Latin-1 has control characters. Unassigned positions map
+// to the replacement character.
+var windows1252 = [32]rune{
+	'€', utf8.RuneError, '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', utf8.RuneError, 'Ž', utf8.RuneError,
+	utf8.RuneError, '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', utf8.RuneError, 'ž', 'Ÿ',
+}
+
+// ImageToPNG converts an image in any format known to the image package
+// into PNG.
+func ImageToPNG(data []byte) ([]byte, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errs.NewWithCause("unable to decode image", err)
+	}
+	var buffer bytes.Buffer
+	if err = png.Encode(&buffer, img); err != nil {
+		return nil, errs.NewWithCause("unable to encode PNG", err)
+	}
+	return buffer.Bytes(), nil
+}
+
+// HTMLToPlainText extracts the text from HTML. Tags are removed, the
+// content of scripts and styles is dropped, whitespace is collapsed, block
+// elements start new lines and character references are decoded.
+func HTMLToPlainText(data []byte) ([]byte, error) {
+	var buffer strings.Builder
+	in := string(data)
+	pendingSpace := false
+	skipUntil := ""
+	for len(in) != 0 {
+		i := strings.IndexByte(in, '<')
+		if i < 0 {
+			i = len(in)
+		}
+		if skipUntil == "" {
+			for _, ch := range html.UnescapeString(in[:i]) {
+				if unicode.IsSpace(ch) && ch != '\u00a0' { // Non-breaking spaces are kept
+					pendingSpace = true
+					continue
+				}
+				if pendingSpace {
+					if buffer.Len() != 0 && !strings.HasSuffix(buffer.String(), "\n") {
+						buffer.WriteByte(' ')
+					}
+					pendingSpace = false
+				}
+				buffer.WriteRune(ch)
+			}
+		}
+		in = in[i:]
+		if len(in) == 0 {
+			break
+		}
+		end := strings.IndexByte(in, '>')
+		if end < 0 {
+			break
+		}
+		tag := strings.ToLower(strings.TrimSpace(in[1:end]))
+		in = in[end+1:]
+		if strings.HasPrefix(tag, "!--") {
+			if commentEnd := strings.Index(in, "-->"); commentEnd >= 0 && !strings.HasSuffix(tag, "--") {
+				in = in[commentEnd+3:]
+			}
+			continue
+		}
+		closing := strings.HasPrefix(tag, "/")
+		name := strings.TrimPrefix(tag, "/")
+		if space := strings.IndexFunc(name, func(r rune) bool { return unicode.IsSpace(r) || r == '/' }); space >= 0 {
+			name = name[:space]
+		}
+		if skipUntil != "" {
+			if closing && name == skipUntil {
+				skipUntil = ""
+			}
+			continue
+		}
+		switch name {
+		case "script", "style", "head", "title":
+			if !closing {
+				skipUntil = name
+			}
+		case "br", "p", "div", "li", "tr", "h1", "h2", "h3", "h4", "h5", "h6", "blockquote", "pre", "table", "ul", "ol":
+			if buffer.Len() != 0 && !strings.HasSuffix(buffer.String(), "\n") {
+				buffer.WriteByte('\n')
+			}
+			pendingSpace = false
+		case "td", "th":
+			if closing {
+				pendingSpace = true
+			}
+		}
+	}
+	return []byte(strings.TrimSpace(buffer.String())), nil
+}
+
+// RTFToPlainText extracts the text from RTF. Formatting is discarded, as
+// are destinations such as font tables and embedded pictures.
+func RTFToPlainText(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, []byte("{\\rtf")) {
+		return nil, errs.New("not RTF")
+	}
+	type group struct {
+		skip        bool
+		unicodeSkip int
+	}
+	var buffer bytes.Buffer
+	stack := []group{{unicodeSkip: 1}}
+	pendingSkip := 0
+	for i := 0; i < len(data); i++ {
+		current := &stack[len(stack)-1]
+		ch := data[i]
+		switch ch {
+		case '{':
+			stack = append(stack, *current)
+			continue
+		case '}':
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+			continue
+		case '\r', '\n':
+			continue
+		case '\\':
+		default:
+			if pendingSkip > 0 {
+				pendingSkip--
+			} else if !current.skip {
+				buffer.WriteByte(ch)
+			}
+			continue
+		}
+		// Control word or symbol
+		i++
+		if i >= len(data) {
+			break
+		}
+		ch = data[i]
+		switch {
+		case ch == '\\' || ch == '{' || ch == '}':
+			if pendingSkip > 0 {
+				pendingSkip--
+			} else if !current.skip {
+				buffer.WriteByte(ch)
+			}
+		case ch == '*':
+			current.skip = true
+		case ch == '\'':
+			if i+2 < len(data) {
+				if v, err := strconv.ParseUint(string(data[i+1:i+3]), 16, 8); err == nil {
+					if pendingSkip > 0 {
+						pendingSkip--
+					} else if !current.skip {
+						// Assume Windows-1252, which only differs from
+						// Latin-1 in the range 0x80-0x9F.
+						r := rune(v)
+						if r >= 0x80 && r <= 0x9F {
+							r = windows1252[r-0x80]
+						}
+						buffer.WriteRune(r)
+					}
+				}
+				i += 2
+			}
+		case ch == '~':
+			if !current.skip {
+				buffer.WriteRune(' ')
+			}
+		case ch >= 'a' && ch <= 'z':
+			start := i
+			for i < len(data) && data[i] >= 'a' && data[i] <= 'z' {
+				i++
+			}
+			word := string(data[start:i])
+			numStart := i
+			if i < len(data) && data[i] == '-' {
+				i++
+			}
+			for i < len(data) && data[i] >= '0' && data[i] <= '9' {
+				i++
+			}
+			param, hasParam := 0, i > numStart
+			if hasParam {
+				param, _ = strconv.Atoi(string(data[numStart:i]))
+			}
+			if i >= len(data) || data[i] != ' ' {
+				i-- // The delimiter is part of the text
+			}
+			switch word {
+			case "par", "line":
+				if !current.skip {
+					buffer.WriteByte('\n')
+				}
+			case "tab":
+				if !current.skip {
+					buffer.WriteByte('\t')
+				}
+			case "uc":
+				current.unicodeSkip = param
+			case "u":
+				if !current.skip {
+					if param < 0 {
+						param += 65536
+					}
+					r := rune(param)
+					if !utf8.ValidRune(r) {
+						r = utf8.RuneError
+					}
+					buffer.WriteRune(r)
+				}
+				pendingSkip = current.unicodeSkip
+			case "fonttbl", "colortbl", "stylesheet", "info", "pict", "object", "header", "footer", "listtable", "listoverridetable", "generator":
+				current.skip = true
+			}
+		}
+	}
+	return bytes.TrimSpace(buffer.Bytes()), nil
+}
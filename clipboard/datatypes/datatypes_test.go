@@ -0,0 +1,119 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package datatypes_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"io"
+	"testing"
+
+	"github.com/richardwilkes/ux/clipboard/datatypes"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/image/bmp"
+	"golang.org/x/image/tiff"
+)
+
+func TestConformance(t *testing.T) {
+	assert.True(t, datatypes.ConformsTo(datatypes.PNG, datatypes.PNG))
+	assert.True(t, datatypes.ConformsTo(datatypes.PNG, datatypes.Image))
+	assert.True(t, datatypes.ConformsTo(datatypes.PNG, datatypes.Generic))
+	assert.True(t, datatypes.ConformsTo(datatypes.FileURL, datatypes.URL))
+	assert.False(t, datatypes.ConformsTo(datatypes.Image, datatypes.PNG))
+	assert.False(t, datatypes.ConformsTo(datatypes.PlainText, datatypes.Image))
+
+	defer datatypes.SaveRegistry()()
+	custom := datatypes.DataType{UTI: "com.example.widgets", Mime: "application/x-example-widgets"}
+	datatypes.Register(custom, datatypes.Data)
+	assert.Equal(t, custom, datatypes.ByUTI[custom.UTI])
+	assert.Equal(t, custom, datatypes.ByMime[custom.Mime])
+	assert.True(t, datatypes.ConformsTo(custom, datatypes.Generic))
+	assert.False(t, datatypes.CanProvide(datatypes.PlainText, []datatypes.DataType{custom}))
+	datatypes.RegisterConverter(custom, datatypes.PlainText, func(data []byte) ([]byte, error) {
+		return bytes.ToUpper(data), nil
+	})
+	assert.True(t, datatypes.CanProvide(datatypes.PlainText, []datatypes.DataType{custom}))
+	get := func(dataType datatypes.DataType) [][]byte {
+		if dataType == custom {
+			return [][]byte{[]byte("one"), []byte("two")}
+		}
+		return nil
+	}
+	assert.Equal(t, [][]byte{[]byte("ONE"), []byte("TWO")}, datatypes.Provide(datatypes.PlainText, []datatypes.DataType{custom}, get))
+}
+
+func TestRestoreRegistry(t *testing.T) {
+	custom := datatypes.DataType{UTI: "com.example.gadgets", Mime: "application/x-example-gadgets"}
+	restore := datatypes.SaveRegistry()
+	datatypes.Register(custom, datatypes.Data)
+	datatypes.RegisterConverter(custom, datatypes.PlainText, func(data []byte) ([]byte, error) { return data, nil })
+	restore()
+	_, exists := datatypes.ByUTI[custom.UTI]
+	assert.False(t, exists)
+	_, exists = datatypes.ByMime[custom.Mime]
+	assert.False(t, exists)
+	assert.False(t, datatypes.ConformsTo(custom, datatypes.Generic))
+	assert.False(t, datatypes.CanProvide(datatypes.PlainText, []datatypes.DataType{custom}))
+}
+
+func TestProvideText(t *testing.T) {
+	data := map[datatypes.DataType][]byte{
+		datatypes.HTMLText: []byte("<html><head><title>x</title></head><body><p>Hello,\n   <b>world</b> &amp; more</p><p>Second</p></body></html>"),
+	}
+	get := func(dataType datatypes.DataType) [][]byte {
+		if one, ok := data[dataType]; ok {
+			return [][]byte{one}
+		}
+		return nil
+	}
+	available := []datatypes.DataType{datatypes.HTMLText}
+	assert.Equal(t, [][]byte{[]byte("Hello, world & more\nSecond")}, datatypes.Provide(datatypes.PlainText, available, get))
+	assert.Equal(t, [][]byte{data[datatypes.HTMLText]}, datatypes.Provide(datatypes.Text, available, get))
+
+	text, err := datatypes.RTFToPlainText([]byte(`{\rtf1\ansi{\fonttbl\f0\fswiss Helvetica;}\f0\pard Caf\'e9 {\b bold}\par\u8364? euro}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "Café bold\n€ euro", string(text))
+
+	text, err = datatypes.RTFToPlainText([]byte(`{\rtf1\ansi \'93Quoted\'94 \'80 \'97 \'81}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "“Quoted” € — \ufffd", string(text))
+}
+
+func TestProvideImage(t *testing.T) {
+	img := image.NewPaletted(image.Rect(0, 0, 2, 2), color.Palette{color.Black, color.White})
+	for _, tc := range []struct {
+		dataType datatypes.DataType
+		encode   func(w io.Writer, m image.Image) error
+	}{
+		{dataType: datatypes.GIF, encode: func(w io.Writer, m image.Image) error { return gif.Encode(w, m, nil) }},
+		{dataType: datatypes.JPEG, encode: func(w io.Writer, m image.Image) error { return jpeg.Encode(w, m, nil) }},
+		{dataType: datatypes.TIFF, encode: func(w io.Writer, m image.Image) error { return tiff.Encode(w, m, nil) }},
+		{dataType: datatypes.BMP, encode: bmp.Encode},
+	} {
+		var buffer bytes.Buffer
+		assert.NoError(t, tc.encode(&buffer, img), tc.dataType.UTI)
+		get := func(dataType datatypes.DataType) [][]byte {
+			if dataType == tc.dataType {
+				return [][]byte{buffer.Bytes()}
+			}
+			return nil
+		}
+		result := datatypes.Provide(datatypes.PNG, []datatypes.DataType{tc.dataType}, get)
+		if assert.Len(t, result, 1, tc.dataType.UTI) {
+			decoded, format, err := image.Decode(bytes.NewReader(result[0]))
+			assert.NoError(t, err, tc.dataType.UTI)
+			assert.Equal(t, "png", format, tc.dataType.UTI)
+			assert.Equal(t, img.Bounds(), decoded.Bounds(), tc.dataType.UTI)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package datatypes
+
+// SaveRegistry takes a snapshot of the registered data types and converters
+// and returns a function that puts them back, so that tests can register
+// their own without affecting other tests.
+func SaveRegistry() (restore func()) {
+	savedByUTI := make(map[string]DataType, len(ByUTI))
+	for k, v := range ByUTI {
+		savedByUTI[k] = v
+	}
+	savedByMime := make(map[string]DataType, len(ByMime))
+	for k, v := range ByMime {
+		savedByMime[k] = v
+	}
+	savedSupertypes := make(map[string][]string, len(supertypes))
+	for k, v := range supertypes {
+		savedSupertypes[k] = v
+	}
+	savedConversions := make(map[string][]conversion, len(conversions))
+	for k, v := range conversions {
+		savedConversions[k] = v
+	}
+	return func() {
+		ByUTI = savedByUTI
+		ByMime = savedByMime
+		supertypes = savedSupertypes
+		conversions = savedConversions
+	}
+}
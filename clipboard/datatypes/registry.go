@@ -0,0 +1,140 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package datatypes
+
+import (
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/log/jot"
+)
+
+// Converter transforms data of one type into another.
+type Converter func(data []byte) ([]byte, error)
+
+type conversion struct {
+	from      DataType
+	converter Converter
+}
+
+type source struct {
+	dataType  DataType
+	converter Converter
+}
+
+var (
+	supertypes  = make(map[string][]string)
+	conversions = make(map[string][]conversion)
+)
+
+// Register adds the data type to ByUTI and, if its MimeType isn't already
+// present, to ByMime. 'conformsTo' lists the types it is a more specific form
+// of, for example, PNG conforms to Image. Registering a UTI a second time
+// replaces the earlier registration. Registration is normally done during
+// initialization, before any clipboard or drag operations take place.
+func Register(dataType DataType, conformsTo ...DataType) {
+	ByUTI[dataType.UTI] = dataType
+	// The MimeTypes may not be unique, so the first entry will be the one
+	// found by ByMime[].
+	if _, exists := ByMime[dataType.Mime]; !exists {
+		ByMime[dataType.Mime] = dataType
+	}
+	parents := make([]string, 0, len(conformsTo))
+	for _, one := range conformsTo {
+		parents = append(parents, one.UTI)
+	}
+	supertypes[dataType.UTI] = parents
+}
+
+// ConformsTo returns true if the data type is the same as, or a more
+// specific form of, the other data type.
+func ConformsTo(dataType, other DataType) bool {
+	return conformsTo(dataType.UTI, other.UTI, make(map[string]bool))
+}
+
+func conformsTo(uti, other string, visited map[string]bool) bool {
+	if uti == other {
+		return true
+	}
+	if visited[uti] {
+		return false
+	}
+	visited[uti] = true
+	for _, parent := range supertypes[uti] {
+		if conformsTo(parent, other, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// RegisterConverter registers a converter that can produce data of the 'to'
+// type from data of the 'from' type. Converters registered later are tried
+// before those registered earlier, allowing the standard converters to be
+// overridden.
+func RegisterConverter(from, to DataType, converter Converter) {
+	conversions[to.UTI] = append([]conversion{{from: from, converter: converter}}, conversions[to.UTI]...)
+}
+
+// CanProvide returns true if data of the wanted type can be obtained from
+// one of the available types, either directly, because the available type
+// conforms to it, or through a registered converter.
+func CanProvide(wanted DataType, available []DataType) bool {
+	return len(sources(wanted, available)) != 0
+}
+
+// Provide obtains data of the wanted type from one of the available types,
+// using 'get' to retrieve the data for an available type. Data of the wanted
+// type is preferred, followed by data that conforms to it and finally data
+// that can be converted to it.
+func Provide(wanted DataType, available []DataType, get func(dataType DataType) [][]byte) [][]byte {
+	for _, src := range sources(wanted, available) {
+		data := get(src.dataType)
+		if len(data) == 0 {
+			continue
+		}
+		if src.converter == nil {
+			return data
+		}
+		result := make([][]byte, 0, len(data))
+		for _, one := range data {
+			converted, err := src.converter(one)
+			if err != nil {
+				jot.Warn(errs.NewWithCause("unable to convert "+src.dataType.UTI+" to "+wanted.UTI, err))
+				continue
+			}
+			result = append(result, converted)
+		}
+		if len(result) != 0 {
+			return result
+		}
+	}
+	return nil
+}
+
+func sources(wanted DataType, available []DataType) []source {
+	var list []source
+	for _, one := range available {
+		if one.UTI == wanted.UTI {
+			list = append(list, source{dataType: one})
+		}
+	}
+	for _, one := range available {
+		if one.UTI != wanted.UTI && ConformsTo(one, wanted) {
+			list = append(list, source{dataType: one})
+		}
+	}
+	for _, c := range conversions[wanted.UTI] {
+		for _, one := range available {
+			if ConformsTo(one, c.from) && !ConformsTo(one, wanted) {
+				list = append(list, source{dataType: one, converter: c.converter})
+			}
+		}
+	}
+	return list
+}
@@ -14,6 +14,10 @@ var (
 	None       = DataType{}                                                           // The empty type; not a valid type
 	Generic    = DataType{UTI: "public.item"}                                         // Generic base type
 	Data       = DataType{UTI: "public.data", Mime: "application/octet-stream"}       // Bytes
+	Text       = DataType{UTI: "public.text"}                                         // Base type for text
+	Image      = DataType{UTI: "public.image"}                                        // Base type for images
+	Audio      = DataType{UTI: "public.audio"}                                        // Base type for audio
+	Movie      = DataType{UTI: "public.movie"}                                        // Base type for movies
 	URL        = DataType{UTI: "public.url", Mime: "application/octet-stream"}        // URL
 	FileURL    = DataType{UTI: "public.file-url", Mime: "application/octet-stream"}   // File URL
 	PlainText  = DataType{UTI: "public.utf8-plain-text", Mime: "text/plain"}          // UTF-8 encoded text
@@ -45,16 +49,23 @@ type DataType struct {
 }
 
 func init() {
-	for _, one := range []DataType{
-		Generic, Data, URL, FileURL, PlainText, RTFText, HTMLText, XMLText,
-		JPEG, TIFF, PNG, XBM, BMP, ICO, GIF, MP3, MPEG4Audio, AIFF, AVI, MPEG,
-		MPEG4, PDF,
-	} {
-		ByUTI[one.UTI] = one
-		// The MimeTypes may not be unique, so the first entry will be the one
-		// found by ByMime[].
-		if _, exists := ByMime[one.Mime]; !exists {
-			ByMime[one.Mime] = one
-		}
+	Register(Generic)
+	Register(Data, Generic)
+	for _, one := range []DataType{Text, Image, Audio, Movie, URL, PDF} {
+		Register(one, Data)
 	}
+	Register(FileURL, URL)
+	for _, one := range []DataType{PlainText, RTFText, HTMLText, XMLText} {
+		Register(one, Text)
+	}
+	for _, one := range []DataType{JPEG, TIFF, PNG, XBM, BMP, ICO, GIF} {
+		Register(one, Image)
+	}
+	for _, one := range []DataType{MP3, MPEG4Audio, AIFF} {
+		Register(one, Audio)
+	}
+	for _, one := range []DataType{AVI, MPEG, MPEG4} {
+		Register(one, Movie)
+	}
+	registerStandardConverters()
 }
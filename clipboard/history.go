@@ -0,0 +1,136 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package clipboard
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	"github.com/richardwilkes/ux/clipboard/datatypes"
+)
+
+// HistoryEntry holds data that was placed on the clipboard.
+type HistoryEntry struct {
+	When time.Time
+	Data []map[datatypes.DataType][]byte
+}
+
+// Text returns the plain text of the first item, or an empty string if it
+// has none.
+func (e *HistoryEntry) Text() string {
+	for _, item := range e.Data {
+		for dt, data := range item {
+			if dt.UTI == datatypes.PlainText.UTI {
+				return string(data)
+			}
+		}
+		break
+	}
+	return ""
+}
+
+// String returns a short, single-line description of the entry.
+func (e *HistoryEntry) String() string {
+	text := strings.Join(strings.Fields(e.Text()), " ")
+	if text == "" {
+		var names []string
+		for _, item := range e.Data {
+			for dt := range item {
+				names = append(names, dt.UTI)
+			}
+			break
+		}
+		text = strings.Join(names, ", ")
+	}
+	const maxLength = 60
+	if runes := []rune(text); len(runes) > maxLength {
+		text = string(runes[:maxLength-1]) + "…"
+	}
+	return e.When.Format("15:04:05") + "  " + text
+}
+
+func (e *HistoryEntry) equal(data []map[datatypes.DataType][]byte) bool {
+	if len(e.Data) != len(data) {
+		return false
+	}
+	for i, item := range e.Data {
+		if len(item) != len(data[i]) {
+			return false
+		}
+		for dt, one := range item {
+			other, ok := data[i][dt]
+			if !ok || !bytes.Equal(one, other) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// History is a ring that records the most recent data placed on the
+// clipboard.
+type History struct {
+	entries  []*HistoryEntry
+	capacity int
+}
+
+// NewHistory creates a new history that holds up to 'capacity' entries. Once
+// full, the oldest entry is discarded to make room for a new one.
+func NewHistory(capacity int) *History {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &History{capacity: capacity}
+}
+
+// Capacity returns the maximum number of entries the history holds.
+func (h *History) Capacity() int {
+	return h.capacity
+}
+
+// Len returns the number of entries in the history.
+func (h *History) Len() int {
+	return len(h.entries)
+}
+
+// Entries returns the entries in the history, most recent first.
+func (h *History) Entries() []*HistoryEntry {
+	result := make([]*HistoryEntry, len(h.entries))
+	for i, one := range h.entries {
+		result[len(h.entries)-1-i] = one
+	}
+	return result
+}
+
+// Add records the data. If the same data is already present, its entry is
+// moved to the front rather than being duplicated.
+func (h *History) Add(data []map[datatypes.DataType][]byte) {
+	for i, one := range h.entries {
+		if one.equal(data) {
+			copy(h.entries[i:], h.entries[i+1:])
+			h.entries = h.entries[:len(h.entries)-1]
+			break
+		}
+	}
+	if len(h.entries) == h.capacity {
+		copy(h.entries, h.entries[1:])
+		h.entries = h.entries[:len(h.entries)-1]
+	}
+	h.entries = append(h.entries, &HistoryEntry{
+		When: time.Now(),
+		Data: copyItems(data),
+	})
+}
+
+// Clear removes all entries from the history.
+func (h *History) Clear() {
+	h.entries = nil
+}
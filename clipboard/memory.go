@@ -0,0 +1,96 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package clipboard
+
+import "github.com/richardwilkes/ux/clipboard/datatypes"
+
+// MemoryBackend is a Backend that keeps the clipboard contents in memory,
+// without involving the platform. It is useful for headless tests and for
+// keeping an application's clipboard private.
+type MemoryBackend struct {
+	items       []map[datatypes.DataType][]byte
+	primary     string
+	changeCount int
+}
+
+// NewMemoryBackend creates a new, empty, in-memory backend.
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{}
+}
+
+// Clear implements Backend.
+func (b *MemoryBackend) Clear() {
+	b.items = nil
+	b.changeCount++
+}
+
+// ChangeCount implements Backend.
+func (b *MemoryBackend) ChangeCount() int {
+	return b.changeCount
+}
+
+// Types implements Backend.
+func (b *MemoryBackend) Types() []datatypes.DataType {
+	var types []datatypes.DataType
+	seen := make(map[string]bool)
+	for _, item := range b.items {
+		for dt := range item {
+			if !seen[dt.UTI] {
+				seen[dt.UTI] = true
+				types = append(types, dt)
+			}
+		}
+	}
+	return types
+}
+
+// Data implements Backend.
+func (b *MemoryBackend) Data(dataType datatypes.DataType) [][]byte {
+	var result [][]byte
+	for _, item := range b.items {
+		for dt, data := range item {
+			if dt.UTI == dataType.UTI {
+				result = append(result, data)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// SetData implements Backend.
+func (b *MemoryBackend) SetData(data []map[datatypes.DataType][]byte) {
+	b.items = copyItems(data)
+	b.changeCount++
+}
+
+// PrimaryText implements Backend.
+func (b *MemoryBackend) PrimaryText() string {
+	return b.primary
+}
+
+// SetPrimaryText implements Backend.
+func (b *MemoryBackend) SetPrimaryText(text string) {
+	b.primary = text
+}
+
+// copyItems makes a deep copy of the items, so that later changes by the
+// caller don't affect the stored data.
+func copyItems(items []map[datatypes.DataType][]byte) []map[datatypes.DataType][]byte {
+	result := make([]map[datatypes.DataType][]byte, len(items))
+	for i, item := range items {
+		m := make(map[datatypes.DataType][]byte, len(item))
+		for dt, data := range item {
+			m[dt] = append([]byte(nil), data...)
+		}
+		result[i] = m
+	}
+	return result
+}
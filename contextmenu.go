@@ -0,0 +1,107 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"runtime"
+
+	"github.com/richardwilkes/toolbox"
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ux/keys"
+)
+
+// ContextMenu defines the methods a context menu must provide. *menu.Menu
+// satisfies this interface.
+//
+// A panel's ContextMenuCallback is called with the location, in panel
+// coordinates, that a context menu was requested for, either by a right-click
+// or the platform's context menu key, and should return nil if no menu should
+// be shown. Before it is called, the keyboard focus is given to the nearest
+// panel that can take it, so that commands sent to the focus, such as those
+// added with menu.InsertActionItemForContextMenu(), act upon the panel the
+// menu was requested for.
+type ContextMenu interface {
+	Popup(wnd *Window, where geom.Rect, currentIndex int)
+	Dispose()
+}
+
+// CanPerformCmd returns true if this panel or one of its ancestors can
+// perform the command.
+func (p *Panel) CanPerformCmd(source interface{}, id int) bool {
+	return p.cmdResponder(source, id) != nil
+}
+
+// PerformCmd asks the first panel, starting with this panel and moving up
+// through its ancestors, that can perform the command to do so. Returns true
+// if one did.
+func (p *Panel) PerformCmd(source interface{}, id int) bool {
+	if responder := p.cmdResponder(source, id); responder != nil {
+		if responder.PerformCmdCallback != nil {
+			responder.PerformCmdCallback(source, id)
+		}
+		return true
+	}
+	return false
+}
+
+func (p *Panel) cmdResponder(source interface{}, id int) *Panel {
+	for panel := p; panel != nil; panel = panel.parent {
+		if panel.Enabled() && panel.CanPerformCmdCallback != nil && panel.CanPerformCmdCallback(source, id) {
+			return panel
+		}
+	}
+	return nil
+}
+
+// isContextMenuClick returns true if the mouse press should bring up a
+// context menu.
+func isContextMenuClick(button int, mod keys.Modifiers) bool {
+	if button == ButtonRight {
+		return true
+	}
+	return runtime.GOOS == toolbox.MacOS && button == ButtonLeft && mod&keys.NonStickyModifiers == keys.ControlModifier
+}
+
+// isContextMenuKey returns true if the key press should bring up a context
+// menu.
+func isContextMenuKey(keyCode int, mod keys.Modifiers) bool {
+	mod &= keys.NonStickyModifiers
+	return (keyCode == keys.ContextMenu.Code && mod == 0) || (keyCode == keys.F10.Code && mod == keys.ShiftModifier)
+}
+
+// showContextMenu shows the context menu provided by the panel, or the
+// nearest of its ancestors that has a ContextMenuCallback. 'where' is in root
+// coordinates. Returns true if a panel with a ContextMenuCallback was found,
+// whether or not it chose to provide a menu.
+func (w *Window) showContextMenu(panel *Panel, where geom.Point) bool {
+	target := panel
+	for target != nil && (!target.Enabled() || target.ContextMenuCallback == nil) {
+		target = target.parent
+	}
+	if target == nil {
+		return false
+	}
+	for p := panel; p != nil; p = p.parent {
+		if p.Focusable() {
+			w.SetFocus(p)
+			break
+		}
+	}
+	if menu := target.ContextMenuCallback(target.PointFromRoot(where)); menu != nil {
+		menu.Popup(w, geom.Rect{Point: where}, -1)
+		menu.Dispose()
+		if w.root.overlay != nil {
+			// The menu is being shown in-window, so let it track the rest of
+			// the gesture that opened it.
+			w.lastMouseDownPanel = w.root.overlay
+		}
+	}
+	return true
+}
@@ -0,0 +1,72 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package dialog
+
+import (
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/log/jot"
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ux/clipboard"
+	"github.com/richardwilkes/ux/icons"
+	"github.com/richardwilkes/ux/ids"
+	"github.com/richardwilkes/ux/layout/align"
+	"github.com/richardwilkes/ux/layout/flex"
+	"github.com/richardwilkes/ux/widget/list"
+	"github.com/richardwilkes/ux/widget/scrollarea"
+	"github.com/richardwilkes/ux/widget/scrollarea/behavior"
+)
+
+// PasteFromHistoryPrompt displays a dialog listing the entries in the
+// clipboard history, most recent first. If the OK button is pressed or an
+// entry is double-clicked, the chosen entry is placed back on the clipboard
+// and returned along with ids.ModalResponseOK. If the Cancel button was
+// pressed, nil is returned along with ids.ModalResponseCancel. If 'history'
+// is nil, the clipboard's current history is used.
+func PasteFromHistoryPrompt(history *clipboard.History) (entry *clipboard.HistoryEntry, code int) {
+	if history == nil {
+		if history = clipboard.CurrentHistory(); history == nil {
+			return nil, ids.ModalResponseCancel
+		}
+	}
+	entries := history.Entries()
+	lst := list.New()
+	for _, one := range entries {
+		lst.Append(one)
+	}
+	if len(entries) != 0 {
+		lst.Select(false, 0)
+	}
+	scroller := scrollarea.New().SetContent(lst.AsPanel(), behavior.Fill)
+	panel := newPromptPanel(i18n.Text("Paste from History"), i18n.Text("Choose the clipboard contents to paste."), scroller.AsPanel())
+	flex.NewData().HAlign(align.Fill).VAlign(align.Fill).HGrab(true).VGrab(true).SizeHint(geom.Size{Width: 400, Height: 200}).Apply(scroller)
+	dialog, err := NewDialog(icons.Question(), panel, []*ButtonInfo{NewCancelButtonInfo(), NewOKButtonInfoWithTitle(i18n.Text("Paste"))})
+	if err != nil {
+		jot.Error(err)
+		return nil, ids.ModalResponseCancel
+	}
+	okButton := dialog.Button(ids.ModalResponseOK)
+	okButton.SetEnabled(lst.Selection.Count() == 1)
+	lst.NewSelectionCallback = func() {
+		okButton.SetEnabled(lst.Selection.Count() == 1)
+	}
+	lst.DoubleClickCallback = func() {
+		if okButton.Enabled() {
+			okButton.Click()
+		}
+	}
+	if code = dialog.RunModal(); code == ids.ModalResponseOK {
+		if index := lst.Selection.FirstSet(); index >= 0 && index < len(entries) {
+			entry = entries[index]
+			clipboard.SetData(entry.Data)
+			return entry, code
+		}
+	}
+	return nil, ids.ModalResponseCancel
+}
@@ -0,0 +1,169 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package dialog
+
+import (
+	"context"
+
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ux"
+	"github.com/richardwilkes/ux/draw"
+	"github.com/richardwilkes/ux/ids"
+	"github.com/richardwilkes/ux/layout"
+	"github.com/richardwilkes/ux/layout/align"
+	"github.com/richardwilkes/ux/layout/flex"
+	"github.com/richardwilkes/ux/widget"
+	"github.com/richardwilkes/ux/widget/label"
+)
+
+// ProgressReporter is called by a ProgressJob to update the progress dialog.
+// The fraction should be a value from 0 to 1. An empty status leaves the
+// current status text unchanged. May be called from any goroutine. This is an
+// alias, so any function with the same signature may be used.
+type ProgressReporter = func(fraction float64, status string)
+
+// ProgressJob is a function that performs work on a background goroutine
+// while a progress dialog is displayed. It should stop and return promptly
+// once the context is cancelled.
+type ProgressJob func(ctx context.Context, report ProgressReporter) error
+
+// ProgressDialog holds information about a progress dialog.
+type ProgressDialog struct {
+	*Dialog
+	bar      *ux.Panel
+	status   *label.Label
+	cancel   context.CancelFunc
+	done     chan struct{}
+	fraction float64
+	err      error
+}
+
+// RunWithProgress displays a modal progress dialog with the specified primary
+// message and runs the job on a separate goroutine until it completes. The
+// Cancel button cancels the context passed to the job. Returns the error the
+// job returned, if any.
+func RunWithProgress(primary string, job ProgressJob) error {
+	pd, err := NewProgressDialog(primary)
+	if err != nil {
+		return err
+	}
+	return pd.Run(job)
+}
+
+// NewProgressDialog creates a new progress dialog. To run a job with it, you
+// must call .Run() on the returned dialog. If the dialog window cannot be
+// created, nil will be returned.
+func NewProgressDialog(primary string) (*ProgressDialog, error) {
+	pd := &ProgressDialog{}
+	panel := ux.NewPanel()
+	flex.New().VSpacing(layout.DefaultVSpacing * 2).Apply(panel)
+	breakTextIntoLabels(panel, primary, draw.EmphasizedSystemFont)
+	pd.bar = ux.NewPanel()
+	pd.bar.SetSizer(func(hint geom.Size) (min, pref, max geom.Size) {
+		pref = geom.Size{Width: 300, Height: 8}
+		min = geom.Size{Width: 50, Height: 8}
+		max = geom.Size{Width: layout.DefaultMaxSize, Height: 8}
+		return
+	})
+	pd.bar.DrawCallback = pd.drawBar
+	flex.NewData().HAlign(align.Fill).HGrab(true).Apply(pd.bar)
+	panel.AddChild(pd.bar)
+	pd.status = label.New().SetText(" ").SetFont(draw.SmallSystemFont)
+	flex.NewData().HAlign(align.Fill).HGrab(true).Apply(pd.status)
+	panel.AddChild(pd.status.AsPanel())
+	flex.NewData().MinSize(geom.Size{Width: 300}).Apply(panel)
+	var err error
+	if pd.Dialog, err = NewDialog(nil, panel, []*ButtonInfo{NewCancelButtonInfo()}); err != nil {
+		return nil, err
+	}
+	pd.Button(ids.ModalResponseCancel).ClickCallback = pd.Cancel
+	return pd, nil
+}
+
+func (pd *ProgressDialog) drawBar(gc draw.Context, dirty geom.Rect, inLiveResize bool) {
+	rect := pd.bar.ContentRect(false)
+	widget.DrawRoundedRectBase(gc, rect, rect.Height/2, draw.ControlBackgroundInk, draw.ControlEdgeAdjColor)
+	if pd.fraction > 0 {
+		rect.InsetUniform(1)
+		rect.Width *= pd.fraction
+		gc.RoundedRect(rect, rect.Height/2)
+		gc.Fill(draw.ControlAccentColor)
+	}
+}
+
+// Run displays the dialog and runs the job on a separate goroutine until it
+// completes. May only be called once. If the dialog's window is closed before
+// the job completes, the job's context is cancelled and Run waits for the job
+// to return. Returns the error the job returned, if any.
+func (pd *ProgressDialog) Run(job ProgressJob) error {
+	if pd.cancel != nil {
+		return errs.New("progress dialog has already been run")
+	}
+	var ctx context.Context
+	ctx, pd.cancel = context.WithCancel(context.Background())
+	pd.done = make(chan struct{})
+	go pd.runJob(ctx, job)
+	pd.RunModal()
+	pd.cancel()
+	<-pd.done
+	return pd.err
+}
+
+func (pd *ProgressDialog) runJob(ctx context.Context, job ProgressJob) {
+	defer func() {
+		if r := recover(); r != nil {
+			pd.err = errs.Newf("progress job panicked: %v", r)
+		}
+		close(pd.done)
+		ux.Invoke(pd.finish)
+	}()
+	pd.err = job(ctx, pd.report)
+}
+
+func (pd *ProgressDialog) report(fraction float64, status string) {
+	ux.Invoke(func() { pd.SetProgress(fraction, status) })
+}
+
+func (pd *ProgressDialog) finish() {
+	if pd.Window().IsValid() {
+		pd.StopModal(ids.ModalResponseOK)
+	}
+}
+
+// SetProgress updates the progress bar and status text. An empty status
+// leaves the current status text unchanged. Must be called on the UI thread.
+func (pd *ProgressDialog) SetProgress(fraction float64, status string) {
+	if fraction < 0 {
+		fraction = 0
+	} else if fraction > 1 {
+		fraction = 1
+	}
+	if pd.fraction != fraction {
+		pd.fraction = fraction
+		pd.bar.MarkForRedraw()
+	}
+	if status != "" {
+		pd.status.SetText(status)
+	}
+}
+
+// Cancel requests that the running job stop by cancelling its context. The
+// dialog remains visible until the job returns.
+func (pd *ProgressDialog) Cancel() {
+	if pd.cancel != nil {
+		pd.cancel()
+	}
+	if b := pd.Button(ids.ModalResponseCancel); b != nil {
+		b.SetEnabled(false)
+	}
+	pd.status.SetText(i18n.Text("Cancelling…"))
+}
@@ -0,0 +1,109 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package dialog
+
+import (
+	"github.com/richardwilkes/toolbox/log/jot"
+	"github.com/richardwilkes/ux"
+	"github.com/richardwilkes/ux/icons"
+	"github.com/richardwilkes/ux/ids"
+	"github.com/richardwilkes/ux/layout"
+	"github.com/richardwilkes/ux/layout/align"
+	"github.com/richardwilkes/ux/layout/flex"
+	"github.com/richardwilkes/ux/widget/popupmenu"
+	"github.com/richardwilkes/ux/widget/textfield"
+)
+
+// TextValidator is called to determine whether the text entered into a
+// prompt is acceptable. Return true if it is.
+type TextValidator func(text string) bool
+
+// TextPrompt displays a standard prompt dialog with the specified primary
+// and detail messages and a single-line text field initialized to the
+// default value. Embedded line feeds are OK. The validator may be nil. If it
+// is present, the OK button will be disabled until the validator accepts the
+// text. This function returns the text that was entered along with
+// ids.ModalResponseOK if the OK button was pressed or ids.ModalResponseCancel
+// if the Cancel button was pressed. When cancelled, the default value is
+// returned.
+func TextPrompt(primary, detail, defaultValue string, validator TextValidator) (text string, code int) {
+	field := textfield.New().SetText(defaultValue)
+	return runTextPrompt(primary, detail, field, defaultValue, validator)
+}
+
+// PasswordPrompt displays a standard prompt dialog with the specified
+// primary and detail messages and a single-line text field that masks the
+// characters entered. Embedded line feeds are OK. The validator may be nil.
+// If it is present, the OK button will be disabled until the validator
+// accepts the text. This function returns the text that was entered along
+// with ids.ModalResponseOK if the OK button was pressed or
+// ids.ModalResponseCancel if the Cancel button was pressed. When cancelled,
+// an empty string is returned.
+func PasswordPrompt(primary, detail string, validator TextValidator) (text string, code int) {
+	field := textfield.New().SetSecure(true).SetRevealToggle(true)
+	return runTextPrompt(primary, detail, field, "", validator)
+}
+
+func runTextPrompt(primary, detail string, field *textfield.TextField, defaultValue string, validator TextValidator) (text string, code int) {
+	field.SetMinimumTextWidth(200)
+	dialog, err := NewDialog(icons.Question(), newPromptPanel(primary, detail, field.AsPanel()), []*ButtonInfo{NewCancelButtonInfo(), NewOKButtonInfo()})
+	if err != nil {
+		jot.Error(err)
+		return defaultValue, ids.ModalResponseCancel
+	}
+	if validator != nil {
+		okButton := dialog.Button(ids.ModalResponseOK)
+		field.ValidateCallback = func() bool {
+			valid := validator(field.Text())
+			okButton.SetEnabled(valid)
+			return valid
+		}
+		field.Validate()
+	}
+	if code = dialog.RunModal(); code == ids.ModalResponseOK {
+		return field.Text(), code
+	}
+	return defaultValue, code
+}
+
+// ChoicePrompt displays a standard prompt dialog with the specified primary
+// and detail messages and a popup menu holding the choices, with the choice
+// at the initial index selected. Embedded line feeds are OK. This function
+// returns the choice that was selected along with ids.ModalResponseOK if the
+// OK button was pressed or ids.ModalResponseCancel if the Cancel button was
+// pressed. When cancelled, the choice at the initial index is returned.
+func ChoicePrompt(primary, detail string, choices []interface{}, initialIndex int) (choice interface{}, code int) {
+	popup := popupmenu.New()
+	for _, one := range choices {
+		popup.AddItem(one)
+	}
+	popup.SelectIndex(initialIndex)
+	dialog, err := NewDialog(icons.Question(), newPromptPanel(primary, detail, popup.AsPanel()), []*ButtonInfo{NewCancelButtonInfo(), NewOKButtonInfo()})
+	if err != nil {
+		jot.Error(err)
+		return popup.ItemAt(initialIndex), ids.ModalResponseCancel
+	}
+	dialog.Button(ids.ModalResponseOK).SetEnabled(len(choices) != 0)
+	if code = dialog.RunModal(); code == ids.ModalResponseOK {
+		return popup.Selected(), code
+	}
+	return popup.ItemAt(initialIndex), code
+}
+
+func newPromptPanel(primary, detail string, control *ux.Panel) *ux.Panel {
+	panel := ux.NewPanel()
+	flex.New().VSpacing(layout.DefaultVSpacing * 4).Apply(panel)
+	msgPanel := NewMessagePanel(primary, detail)
+	flex.NewData().HAlign(align.Fill).HGrab(true).Apply(msgPanel)
+	panel.AddChild(msgPanel)
+	flex.NewData().HAlign(align.Fill).HGrab(true).Apply(control)
+	panel.AddChild(control)
+	return panel
+}
@@ -43,29 +43,34 @@ type DragInfo struct {
 	DataForType         func(dataType datatypes.DataType) [][]byte
 }
 
-// HasType returns true if the specified data type is present.
+// HasType returns true if the specified data type is present, or can be
+// provided from the data present through conformance or conversion.
 func (di *DragInfo) HasType(dataType datatypes.DataType) bool {
-	for _, dt := range di.ItemTypes {
-		if dt.UTI == dataType.UTI {
-			return true
-		}
-	}
-	return false
+	return datatypes.CanProvide(dataType, di.ItemTypes)
 }
 
 // FirstTypePresent returns the first data type that matches the available
 // data types, or returns datatypes.None.
 func (di *DragInfo) FirstTypePresent(dataType ...datatypes.DataType) datatypes.DataType {
 	for _, one := range dataType {
-		for _, dt := range di.ItemTypes {
-			if dt.UTI == one.UTI {
-				return one
-			}
+		if di.HasType(one) {
+			return one
 		}
 	}
 	return datatypes.None
 }
 
+// withConversions wraps DataForType so that types not present in the drag
+// are provided through conformance or conversion.
+func (di *DragInfo) withConversions() *DragInfo {
+	if get := di.DataForType; get != nil {
+		di.DataForType = func(dataType datatypes.DataType) [][]byte {
+			return datatypes.Provide(dataType, di.ItemTypes, get)
+		}
+	}
+	return di
+}
+
 // ApplyOffset applies the delta to the drag and drag image positions.
 func (di *DragInfo) ApplyOffset(dx, dy float64) {
 	di.DragX += dx
@@ -0,0 +1,346 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"runtime"
+
+	"github.com/richardwilkes/toolbox"
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ux/clipboard/datatypes"
+	"github.com/richardwilkes/ux/draw"
+	"github.com/richardwilkes/ux/keys"
+)
+
+// DragImageOpacity is the opacity used when drawing the drag image.
+var DragImageOpacity = 0.7
+
+// DragSource describes a drag to be started with Panel.StartDrag().
+type DragSource struct {
+	// Image is drawn under the mouse while dragging. If nil, a rendering of
+	// the panel the drag was started from is used instead.
+	Image *draw.Image
+	// ImageOffset is the position of the image's top-left corner relative to
+	// the mouse. If Image is nil, this is ignored and the panel's rendering
+	// is positioned so that it initially lines up with the panel.
+	ImageOffset geom.Point
+	// Items holds the data for each item being dragged, keyed by type, in
+	// the same form as clipboard.SetData(). A nil value indicates the data
+	// for that type should be obtained from the DataProvider when a drop
+	// target asks for it.
+	Items []map[datatypes.DataType][]byte
+	// DataProvider is called to obtain the data for an item and type whose
+	// value in Items is nil.
+	DataProvider func(item int, dataType datatypes.DataType) []byte
+	// Operations holds the operations the source permits.
+	Operations DragOperation
+	// CompletionCallback, if set, is called once the drag concludes with
+	// the operation the drop target chose, or DragOperationNone if the drag
+	// was cancelled or rejected.
+	CompletionCallback func(operation DragOperation)
+}
+
+type dragSession struct {
+	source    *DragSource
+	panel     *Panel
+	wnd       *Window
+	target    *Window
+	where     geom.Point
+	offset    geom.Point
+	mod       keys.Modifiers
+	operation DragOperation
+	sequence  int
+}
+
+var (
+	activeDrag       *dragSession
+	lastDragSequence int
+)
+
+// StartDrag starts a drag from this panel. 'where' is the mouse location in
+// panel coordinates, normally the one passed to the MouseDragCallback. The
+// drag continues until the mouse button is released or Escape is pressed.
+// Returns false if the drag could not be started, which happens if the panel
+// isn't in a window, the source has no items, or another drag is already in
+// progress.
+func (p *Panel) StartDrag(where geom.Point, source *DragSource) bool {
+	wnd := p.Window()
+	if wnd == nil || activeDrag != nil || source == nil || len(source.Items) == 0 {
+		return false
+	}
+	lastDragSequence++
+	s := &dragSession{
+		source:   source,
+		panel:    p,
+		wnd:      wnd,
+		offset:   source.ImageOffset,
+		sequence: lastDragSequence,
+	}
+	if source.Image == nil {
+		s.offset = geom.Point{X: -where.X, Y: -where.Y}
+	}
+	activeDrag = s
+	wnd.ClearTooltip()
+	s.update(wnd, p.PointToRoot(where), 0)
+	return true
+}
+
+// DragInProgress returns true if a drag started by Panel.StartDrag() is in
+// progress.
+func DragInProgress() bool {
+	return activeDrag != nil
+}
+
+// dragInfo creates the DragInfo to pass to drop targets.
+func (s *dragSession) dragInfo() *DragInfo {
+	di := &DragInfo{
+		Sequence:            s.sequence,
+		SourceOperationMask: s.operationMask(),
+		DragX:               s.where.X,
+		DragY:               s.where.Y,
+		DragImageX:          s.where.X + s.offset.X,
+		DragImageY:          s.where.Y + s.offset.Y,
+		ValidItemsForDrop:   len(s.source.Items),
+		DataForType:         s.dataForType,
+	}
+	for _, item := range s.source.Items {
+		for dt := range item {
+			if !hasExactType(di.ItemTypes, dt) {
+				di.ItemTypes = append(di.ItemTypes, dt)
+			}
+		}
+	}
+	return di.withConversions()
+}
+
+func hasExactType(types []datatypes.DataType, dataType datatypes.DataType) bool {
+	for _, one := range types {
+		if one.UTI == dataType.UTI {
+			return true
+		}
+	}
+	return false
+}
+
+// operationMask returns the operations permitted by the source, narrowed by
+// any modifier keys being held down. On macOS, Option requests a copy,
+// Command a move and both together a link. Elsewhere, Control requests a
+// copy, Shift a move and both together a link.
+func (s *dragSession) operationMask() DragOperation {
+	var wanted DragOperation
+	if runtime.GOOS == toolbox.MacOS {
+		switch s.mod & (keys.OptionModifier | keys.CommandModifier) {
+		case keys.OptionModifier:
+			wanted = DragOperationCopy
+		case keys.CommandModifier:
+			wanted = DragOperationMove | DragOperationGeneric
+		case keys.OptionModifier | keys.CommandModifier:
+			wanted = DragOperationLink
+		}
+	} else {
+		switch s.mod & (keys.ControlModifier | keys.ShiftModifier) {
+		case keys.ControlModifier:
+			wanted = DragOperationCopy
+		case keys.ShiftModifier:
+			wanted = DragOperationMove
+		case keys.ControlModifier | keys.ShiftModifier:
+			wanted = DragOperationLink
+		}
+	}
+	if wanted == DragOperationNone {
+		return s.source.Operations
+	}
+	return s.source.Operations & wanted
+}
+
+func (s *dragSession) dataForType(dataType datatypes.DataType) [][]byte {
+	var result [][]byte
+	for i, item := range s.source.Items {
+		for dt, data := range item {
+			if dt.UTI != dataType.UTI {
+				continue
+			}
+			if data == nil && s.source.DataProvider != nil {
+				data = s.source.DataProvider(i, dt)
+			}
+			if data != nil {
+				result = append(result, data)
+			}
+			break
+		}
+	}
+	return result
+}
+
+// targetFor returns the window the drag is over and the location within it,
+// or nil if the drag isn't over a window that accepts the dragged types.
+// 'where' is in the root coordinates of 'wnd', but may lie outside of it.
+// The location in screen coordinates is also returned.
+func (s *dragSession) targetFor(wnd *Window, where geom.Point) (target *Window, pt, screen geom.Point) {
+	rect := wnd.ContentRect()
+	screen = geom.Point{X: where.X + rect.X, Y: where.Y + rect.Y}
+	candidates := make([]*Window, 0, len(windowList)+1)
+	candidates = append(candidates, wnd)
+	for i := len(windowList) - 1; i >= 0; i-- {
+		if windowList[i] != wnd {
+			candidates = append(candidates, windowList[i])
+		}
+	}
+	for _, one := range candidates {
+		if !one.IsValid() {
+			continue
+		}
+		r := one.ContentRect()
+		if r.ContainsPoint(screen) {
+			if !one.acceptsDrag(s.source.Items) {
+				return nil, geom.Point{}, screen
+			}
+			return one, geom.Point{X: screen.X - r.X, Y: screen.Y - r.Y}, screen
+		}
+	}
+	return nil, geom.Point{}, screen
+}
+
+// acceptsDrag returns true if the window has registered for at least one of
+// the types present in the items, or a type that can be provided from them.
+// Windows that have registered datatypes.Generic accept everything, while
+// those that haven't registered any types accept nothing.
+func (w *Window) acceptsDrag(items []map[datatypes.DataType][]byte) bool {
+	var types []datatypes.DataType
+	for _, item := range items {
+		for dt := range item {
+			types = append(types, dt)
+		}
+	}
+	for _, registered := range w.dragTypes {
+		if registered.UTI == datatypes.Generic.UTI || datatypes.CanProvide(registered, types) {
+			return true
+		}
+		for _, dt := range types {
+			if dt.Mime != "" && dt.Mime == registered.Mime {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// update moves the drag to 'where', which is in the root coordinates of
+// 'wnd'.
+func (s *dragSession) update(wnd *Window, where geom.Point, mod keys.Modifiers) {
+	target, pt, screen := s.targetFor(wnd, where)
+	if s.target != nil {
+		s.target.MarkForRedraw()
+	}
+	if target != s.target {
+		if s.target != nil && s.target.DragExitedCallback != nil {
+			s.target.DragExitedCallback()
+		}
+		s.operation = DragOperationNone
+	}
+	entered := target != s.target
+	s.target = target
+	s.where = pt
+	s.mod = mod
+	if target == nil {
+		// Not over one of our windows, so let the platform offer it to other
+		// applications, if it can.
+		s.operation = s.osExternalUpdate(screen)
+		return
+	}
+	s.osExternalLeave()
+	di := s.dragInfo()
+	var op DragOperation
+	if entered {
+		if target.DragEnteredCallback != nil {
+			op = target.DragEnteredCallback(di)
+		}
+	} else if target.DragUpdatedCallback != nil {
+		op = target.DragUpdatedCallback(di)
+	}
+	s.operation = chooseDragOperation(op, di.SourceOperationMask)
+	target.MarkForRedraw()
+}
+
+// chooseDragOperation picks a single operation from those the target
+// returned that the source permits.
+func chooseDragOperation(op, mask DragOperation) DragOperation {
+	op &= mask
+	for _, one := range []DragOperation{DragOperationMove, DragOperationCopy, DragOperationLink, DragOperationGeneric, DragOperationPrivate, DragOperationDelete} {
+		if op&one != 0 {
+			return one
+		}
+	}
+	return DragOperationNone
+}
+
+// drop concludes the drag at 'where', which is in the root coordinates of
+// 'wnd'.
+func (s *dragSession) drop(wnd *Window, where geom.Point, mod keys.Modifiers) {
+	s.update(wnd, where, mod)
+	if s.target == nil && s.osExternalDrop() {
+		// The other application will report the outcome later.
+		if activeDrag == s {
+			activeDrag = nil
+		}
+		return
+	}
+	op := DragOperationNone
+	if target := s.target; target != nil {
+		di := s.dragInfo()
+		if s.operation != DragOperationNone && target.DropIsAcceptableCallback != nil && target.DropIsAcceptableCallback(di) &&
+			target.DropCallback != nil && target.DropCallback(di) {
+			op = s.operation
+			if target.DropFinishedCallback != nil {
+				target.DropFinishedCallback(di)
+			}
+		} else if target.DragExitedCallback != nil {
+			target.DragExitedCallback()
+		}
+	}
+	s.finish(op)
+}
+
+// cancel abandons the drag.
+func (s *dragSession) cancel() {
+	s.osExternalLeave()
+	if s.target != nil && s.target.DragExitedCallback != nil {
+		s.target.DragExitedCallback()
+	}
+	s.finish(DragOperationNone)
+}
+
+func (s *dragSession) finish(op DragOperation) {
+	if activeDrag == s {
+		activeDrag = nil
+	}
+	if s.target != nil {
+		if s.target.DragEndedCallback != nil {
+			s.target.DragEndedCallback()
+		}
+		s.target.MarkForRedraw()
+	}
+	if s.source.CompletionCallback != nil {
+		s.source.CompletionCallback(op)
+	}
+}
+
+// draw the drag image into the target window.
+func (s *dragSession) draw(gc draw.Context) {
+	gc.Save()
+	gc.SetOpacity(DragImageOpacity)
+	gc.Translate(s.where.X+s.offset.X, s.where.Y+s.offset.Y)
+	if s.source.Image != nil {
+		s.source.Image.Draw(gc, geom.Point{})
+	} else {
+		s.panel.Draw(gc, geom.Rect{Size: s.panel.FrameRect().Size}, false)
+	}
+	gc.Restore()
+}
@@ -0,0 +1,24 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import "github.com/richardwilkes/toolbox/xmath/geom"
+
+func (s *dragSession) osExternalUpdate(screen geom.Point) DragOperation {
+	return DragOperationNone // RAW: Implement
+}
+
+func (s *dragSession) osExternalLeave() {
+	// RAW: Implement
+}
+
+func (s *dragSession) osExternalDrop() bool {
+	return false // RAW: Implement
+}
@@ -0,0 +1,176 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+// Package ease provides easing functions for use with animations.
+package ease
+
+import "math"
+
+// Func maps the linear progress of an animation, from 0 to 1, to the
+// progress that should be displayed. Most functions return 0 for 0 and 1 for
+// 1, but may stray outside that range in between.
+type Func func(t float64) float64
+
+// Linear progresses at a constant rate.
+func Linear(t float64) float64 {
+	return t
+}
+
+// InQuad starts slowly and accelerates.
+func InQuad(t float64) float64 {
+	return t * t
+}
+
+// OutQuad starts quickly and decelerates.
+func OutQuad(t float64) float64 {
+	return t * (2 - t)
+}
+
+// InOutQuad accelerates until halfway, then decelerates.
+func InOutQuad(t float64) float64 {
+	if t < 0.5 {
+		return 2 * t * t
+	}
+	return -1 + (4-2*t)*t
+}
+
+// InCubic starts slowly and accelerates, more sharply than InQuad.
+func InCubic(t float64) float64 {
+	return t * t * t
+}
+
+// OutCubic starts quickly and decelerates, more sharply than OutQuad.
+func OutCubic(t float64) float64 {
+	t--
+	return t*t*t + 1
+}
+
+// InOutCubic accelerates until halfway, then decelerates, more sharply than
+// InOutQuad.
+func InOutCubic(t float64) float64 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	t = 2*t - 2
+	return t*t*t/2 + 1
+}
+
+// InSine starts slowly and accelerates, following a sine curve.
+func InSine(t float64) float64 {
+	return 1 - math.Cos(t*math.Pi/2)
+}
+
+// OutSine starts quickly and decelerates, following a sine curve.
+func OutSine(t float64) float64 {
+	return math.Sin(t * math.Pi / 2)
+}
+
+// InOutSine accelerates until halfway, then decelerates, following a sine
+// curve.
+func InOutSine(t float64) float64 {
+	return (1 - math.Cos(t*math.Pi)) / 2
+}
+
+// InExpo starts very slowly and accelerates exponentially.
+func InExpo(t float64) float64 {
+	if t <= 0 {
+		return 0
+	}
+	return math.Pow(2, 10*(t-1))
+}
+
+// OutExpo starts very quickly and decelerates exponentially.
+func OutExpo(t float64) float64 {
+	if t >= 1 {
+		return 1
+	}
+	return 1 - math.Pow(2, -10*t)
+}
+
+// InOutExpo accelerates exponentially until halfway, then decelerates
+// exponentially.
+func InOutExpo(t float64) float64 {
+	switch {
+	case t <= 0:
+		return 0
+	case t >= 1:
+		return 1
+	case t < 0.5:
+		return math.Pow(2, 20*t-10) / 2
+	default:
+		return (2 - math.Pow(2, -20*t+10)) / 2
+	}
+}
+
+const backOvershoot = 1.70158
+
+// InBack pulls back slightly before accelerating forward.
+func InBack(t float64) float64 {
+	return t * t * ((backOvershoot+1)*t - backOvershoot)
+}
+
+// OutBack overshoots the end slightly before settling back.
+func OutBack(t float64) float64 {
+	t--
+	return t*t*((backOvershoot+1)*t+backOvershoot) + 1
+}
+
+// InOutBack pulls back slightly at the start and overshoots slightly at the
+// end.
+func InOutBack(t float64) float64 {
+	const s = backOvershoot * 1.525
+	if t < 0.5 {
+		t *= 2
+		return t * t * ((s+1)*t - s) / 2
+	}
+	t = 2*t - 2
+	return (t*t*((s+1)*t+s) + 2) / 2
+}
+
+// InElastic oscillates with increasing amplitude before snapping to the end.
+func InElastic(t float64) float64 {
+	if t <= 0 || t >= 1 {
+		return t
+	}
+	return -math.Pow(2, 10*t-10) * math.Sin((10*t-10.75)*2*math.Pi/3)
+}
+
+// OutElastic snaps past the end and oscillates with decreasing amplitude
+// until it settles.
+func OutElastic(t float64) float64 {
+	if t <= 0 || t >= 1 {
+		return t
+	}
+	return math.Pow(2, -10*t)*math.Sin((10*t-0.75)*2*math.Pi/3) + 1
+}
+
+// InBounce bounces with increasing height before reaching the end.
+func InBounce(t float64) float64 {
+	return 1 - OutBounce(1-t)
+}
+
+// OutBounce reaches the end quickly, then bounces with decreasing height
+// until it settles.
+func OutBounce(t float64) float64 {
+	const n = 7.5625
+	const d = 2.75
+	switch {
+	case t < 1/d:
+		return n * t * t
+	case t < 2/d:
+		t -= 1.5 / d
+		return n*t*t + 0.75
+	case t < 2.5/d:
+		t -= 2.25 / d
+		return n*t*t + 0.9375
+	default:
+		t -= 2.625 / d
+		return n*t*t + 0.984375
+	}
+}
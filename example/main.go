@@ -11,6 +11,8 @@ package main
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"unicode"
@@ -19,15 +21,20 @@ import (
 	"github.com/richardwilkes/toolbox/log/jot"
 	"github.com/richardwilkes/toolbox/log/jotrotate"
 	"github.com/richardwilkes/toolbox/xio/fs/embedded"
+	"github.com/richardwilkes/toolbox/xio/fs/paths"
 	"github.com/richardwilkes/toolbox/xmath/geom"
 	"github.com/richardwilkes/ux"
 	"github.com/richardwilkes/ux/border"
 	"github.com/richardwilkes/ux/display"
 	"github.com/richardwilkes/ux/draw"
+	"github.com/richardwilkes/ux/ids"
+	"github.com/richardwilkes/ux/keybinding"
+	"github.com/richardwilkes/ux/keybinding/editor"
 	"github.com/richardwilkes/ux/layout/align"
 	"github.com/richardwilkes/ux/layout/flex"
 	"github.com/richardwilkes/ux/layout/flow"
 	"github.com/richardwilkes/ux/menu"
+	"github.com/richardwilkes/ux/palette"
 	"github.com/richardwilkes/ux/widget/browser"
 	"github.com/richardwilkes/ux/widget/button"
 	"github.com/richardwilkes/ux/widget/checkbox"
@@ -46,6 +53,7 @@ import (
 
 var (
 	aboutWindow         *ux.Window
+	prefsWindow         *ux.Window
 	appleCursor         *draw.Cursor
 	homeImg             *draw.Image
 	classicAppleLogoImg *draw.Image
@@ -85,6 +93,8 @@ func finishStartup() {
 		Y: size.Height / 2,
 	})
 
+	loadKeyBindings()
+
 	usable := display.Primary().Usable
 	w1 := createButtonsWindow("Demo #1", usable.Point)
 	frame1 := w1.FrameRect()
@@ -96,6 +106,10 @@ func createButtonsWindow(title string, where geom.Point) *ux.Window {
 	jot.FatalIfErr(err)
 	if bar, global, first := menu.BarForWindow(wnd, nil); !global || first {
 		bar.InsertStdMenus(createAboutWindow, createPreferencesWindow, nil)
+		if m := bar.Menu(ids.EditMenuID); m != nil {
+			m.InsertSeparator(-1)
+			m.InsertActionItem(-1, palette.ShowAction)
+		}
 	}
 
 	content := wnd.Content()
@@ -359,6 +373,13 @@ func createTextFieldsPanel() *ux.Panel {
 		}
 		return true
 	}
+	createTextField("", panel).SetWatermark("Password").SetSecure(true).SetRevealToggle(true)
+	createTextField("", panel).SetWatermark("Phone: (999) 999-9999").SetFormatter(textfield.NewMask("(999) 999-9999"))
+	search := textfield.NewSearchField()
+	search.SetWatermark("Search")
+	search.SearchCallback = func(text string) { jot.Infof("search for %q", text) }
+	flex.NewData().HAlign(align.Fill).HGrab(true).Apply(search)
+	panel.AddChild(search.AsPanel())
 	return panel
 }
 
@@ -407,5 +428,40 @@ func createAboutWindow(item *menu.Item) {
 }
 
 func createPreferencesWindow(item *menu.Item) {
-	jot.Info(item.Title())
+	if prefsWindow == nil {
+		var err error
+		prefsWindow, err = ux.NewWindow(item.Title(), geom.Rect{}, ux.TitledWindowMask|ux.ClosableWindowMask|ux.ResizableWindowMask)
+		if err != nil {
+			jot.Error(err)
+			return
+		}
+		prefsWindow.WillCloseCallback = func() { prefsWindow = nil }
+		content := prefsWindow.Content()
+		content.SetBorder(border.NewEmpty(geom.NewUniformInsets(10)))
+		flex.New().Apply(content)
+		ed := editor.New()
+		flex.NewData().HAlign(align.Fill).VAlign(align.Fill).HGrab(true).VGrab(true).Apply(ed)
+		content.AddChild(ed.AsPanel())
+		prefsWindow.Pack()
+	}
+	prefsWindow.ToFront()
+}
+
+func loadKeyBindings() {
+	dir := paths.AppDataDir()
+	path := filepath.Join(dir, "keybindings.json")
+	if _, err := os.Stat(path); err == nil {
+		if err = keybinding.Load(path); err != nil {
+			jot.Error(err)
+		}
+	}
+	keybinding.AddChangeListener(func() {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			jot.Error(err)
+			return
+		}
+		if err := keybinding.Save(path); err != nil {
+			jot.Error(err)
+		}
+	})
 }
@@ -0,0 +1,54 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import "time"
+
+// FrameInterval is the amount of time between ticks of the frame clock that
+// drives animations and layout transitions.
+var FrameInterval = time.Second / 60
+
+var (
+	frameCallbacks    []func(now time.Time) bool
+	frameClockRunning bool
+)
+
+// AddFrameCallback adds a function to be called on the UI thread at each
+// tick of the frame clock, with the time of the tick. The function remains
+// registered until it returns false. All functions registered with the clock
+// see the same time for a given tick, keeping anything they animate in step.
+// The clock only runs while there are functions registered with it. This
+// should only be called from the UI thread.
+func AddFrameCallback(f func(now time.Time) bool) {
+	frameCallbacks = append(frameCallbacks, f)
+	scheduleFrame()
+}
+
+func scheduleFrame() {
+	if !frameClockRunning && len(frameCallbacks) != 0 {
+		frameClockRunning = true
+		InvokeAfter(frameTick, FrameInterval)
+	}
+}
+
+func frameTick() {
+	frameClockRunning = false
+	now := time.Now()
+	current := frameCallbacks
+	frameCallbacks = nil
+	var remaining []func(now time.Time) bool
+	for _, f := range current {
+		if f(now) {
+			remaining = append(remaining, f)
+		}
+	}
+	frameCallbacks = append(remaining, frameCallbacks...)
+	scheduleFrame()
+}
@@ -59,6 +59,7 @@ type widgetVars struct {
 	Instance   string
 	Vars       []*Var
 	Selectable bool
+	Oriented   bool
 }
 
 var widgetList = []*widgetVars{
@@ -804,6 +805,7 @@ var widgetList = []*widgetVars{
 	{
 		Name:     "ScrollBar",
 		Instance: "s",
+		Oriented: true,
 		Vars: []*Var{
 			{
 				Name:            "backgroundInk",
@@ -881,6 +883,7 @@ var widgetList = []*widgetVars{
 	{
 		Name:     "Separator",
 		Instance: "s",
+		Oriented: true,
 		Vars: []*Var{
 			{
 				Name:            "fillInk",
@@ -983,6 +986,27 @@ var widgetList = []*widgetVars{
 				Comment: "the help text that will show up in an empty field",
 				Redraw:  true,
 			},
+			{
+				Name:    "secure",
+				Type:    typeBool,
+				Comment: "whether the content is obscured by drawing a bullet in place of each character. Secure content cannot be cut or copied",
+				Redraw:  true,
+				Layout:  true,
+			},
+			{
+				Name:    "revealToggle",
+				Type:    typeBool,
+				Comment: "whether a toggle is shown at the trailing edge of a secure field that permits the content to be temporarily revealed",
+				Redraw:  true,
+				Layout:  true,
+			},
+			{
+				Name:    "clearButton",
+				Type:    typeBool,
+				Comment: "whether a button is shown at the trailing edge of a non-empty field that clears its content",
+				Redraw:  true,
+				Layout:  true,
+			},
 			{
 				Name:    "focusedBorder",
 				Type:    typeBorder,
@@ -1018,6 +1042,7 @@ func main() {
 		name := strings.ToLower(w.Name)
 		processTemplate("widget", filepath.Join("..", "widget", name, name+"_gen.go"), w)
 	}
+	processTemplate("uidef", filepath.Join("..", "uidef", "widgets_gen.go"), widgetList)
 }
 
 func processTemplate(name, dstPath string, arg interface{}) {
@@ -1029,6 +1054,7 @@ func processTemplate(name, dstPath string, arg interface{}) {
 		"imports":      imports,
 		"package":      pkg,
 		"comment":      comment,
+		"decoder":      decoder,
 	}).ParseFiles(filepath.Join("tmpl", baseName))
 	fatalIfErr(err)
 	fatalIfErr(tmpl.Execute(&buffer, arg))
@@ -1075,6 +1101,38 @@ func imports(w *widgetVars) []string {
 	return append(all, extractImports(usr)...)
 }
 
+// decoder returns the name of the function in the uidef package that decodes
+// a value of the variable's type, or an empty string if the type cannot be
+// loaded from a UI definition.
+func decoder(v *Var) string {
+	switch v.Type {
+	case typeBool:
+		return "decodeBool"
+	case typeFloat64:
+		return "decodeFloat64"
+	case typeString:
+		return "decodeString"
+	case typeImage:
+		return "decodeImage"
+	case typeFont:
+		return "decodeFont"
+	case typeInk:
+		return "decodeInk"
+	case typeAlignment:
+		return "decodeAlignment"
+	case typeSide:
+		return "decodeSide"
+	case typeState:
+		return "decodeState"
+	case typeDuration:
+		return "decodeDuration"
+	case typeBorder:
+		return "decodeBorder"
+	default:
+		return ""
+	}
+}
+
 func extractImports(m map[string]bool) []string {
 	list := make([]string, 0, len(m))
 	for k := range m {
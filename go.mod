@@ -9,4 +9,6 @@ require (
 	github.com/richardwilkes/toolbox v1.24.0
 	github.com/richardwilkes/win32 v0.0.0-20200126173402-cb9fcf3dc560
 	github.com/stretchr/testify v1.4.0
+	golang.org/x/image v0.0.0-20200119044424-58c23975cae1
+	gopkg.in/yaml.v3 v3.0.0-20200121175148-a6ecf24a6d71
 )
@@ -36,6 +36,10 @@ const (
 	UserBaseID        = 1000
 	MaxUserBaseID     = 1<<30 - 1
 	ContextMenuIDFlag = 1 << 30 // Should be or'd into IDs for context menus
+	// IDs added later are given fixed values below UserBaseID, rather than
+	// being added to the sequence above, so that the values of the existing
+	// IDs, which may have been saved with key bindings, don't change.
+	CommandPaletteItemID = UserBaseID - 1
 )
 
 // Pre-defined modal response codes. Apps should start their codes at
@@ -0,0 +1,197 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package keybinding
+
+import (
+	"strings"
+
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/ux/keys"
+)
+
+var (
+	keysByName       map[string]*keys.Key
+	modifierPrefixes = []struct {
+		prefix string
+		mod    keys.Modifiers
+	}{
+		{prefix: "ctrl+", mod: keys.ControlModifier},
+		{prefix: "control+", mod: keys.ControlModifier},
+		{prefix: "alt+", mod: keys.OptionModifier},
+		{prefix: "opt+", mod: keys.OptionModifier},
+		{prefix: "option+", mod: keys.OptionModifier},
+		{prefix: "shift+", mod: keys.ShiftModifier},
+		{prefix: "cmd+", mod: keys.CommandModifier},
+		{prefix: "command+", mod: keys.CommandModifier},
+		{prefix: "win+", mod: keys.CommandModifier},
+		{prefix: "meta+", mod: keys.CommandModifier},
+	}
+)
+
+// Stroke is a single key press along with the modifier keys held down while
+// it was pressed.
+type Stroke struct {
+	Key       *keys.Key
+	Modifiers keys.Modifiers
+}
+
+// Binding is a sequence of one or more strokes. A binding with more than one
+// stroke, such as Ctrl+K Ctrl+C, is triggered by pressing each of its strokes
+// in turn.
+type Binding []Stroke
+
+// NewBinding creates a new single stroke binding.
+func NewBinding(key *keys.Key, mod keys.Modifiers) Binding {
+	return Binding{{Key: key, Modifiers: mod & keys.NonStickyModifiers}}
+}
+
+// ParseBinding parses text in the form produced by Binding.String(), such as
+// "Ctrl+Shift+P" or "Ctrl+K Ctrl+C". The modifier names are not case
+// sensitive and both the macOS and Windows names are accepted on all
+// platforms.
+func ParseBinding(text string) (Binding, error) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return nil, errs.New("empty key binding")
+	}
+	b := make(Binding, 0, len(fields))
+	for _, field := range fields {
+		s, err := parseStroke(field)
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, s)
+	}
+	return b, nil
+}
+
+func parseStroke(text string) (Stroke, error) {
+	var mod keys.Modifiers
+	remaining := text
+outer:
+	for {
+		lower := strings.ToLower(remaining)
+		for _, one := range modifierPrefixes {
+			if len(lower) > len(one.prefix) && strings.HasPrefix(lower, one.prefix) {
+				mod |= one.mod
+				remaining = remaining[len(one.prefix):]
+				continue outer
+			}
+		}
+		break
+	}
+	key := KeyForName(remaining)
+	if key == nil {
+		return Stroke{}, errs.Newf("unknown key '%s' in key binding '%s'", remaining, text)
+	}
+	return Stroke{Key: key, Modifiers: mod}, nil
+}
+
+// KeyForName returns the key with the name, as returned by KeyName(), or nil.
+// The name is not case sensitive.
+func KeyForName(name string) *keys.Key {
+	if keysByName == nil {
+		keysByName = make(map[string]*keys.Key, len(keys.ByCode))
+		for _, k := range keys.ByCode {
+			keysByName[strings.ToLower(KeyName(k))] = k
+		}
+	}
+	return keysByName[strings.ToLower(name)]
+}
+
+// KeyName returns the name used for the key within key bindings. This is the
+// key's name, except for keys on the numeric keypad, which are prefixed with
+// "Numpad" to distinguish them from their counterparts on the main keyboard.
+func KeyName(k *keys.Key) string {
+	switch k.Code {
+	case keys.NumpadDivide.Code, keys.NumpadMultiply.Code, keys.NumpadAdd.Code, keys.NumpadSubtract.Code,
+		keys.NumpadDecimal.Code, keys.NumpadEnter.Code, keys.Numpad0.Code, keys.Numpad1.Code, keys.Numpad2.Code,
+		keys.Numpad3.Code, keys.Numpad4.Code, keys.Numpad5.Code, keys.Numpad6.Code, keys.Numpad7.Code,
+		keys.Numpad8.Code, keys.Numpad9.Code:
+		return "Numpad" + k.Name
+	default:
+		return k.Name
+	}
+}
+
+// String returns the text representation of the stroke, e.g. "Ctrl+C".
+func (s Stroke) String() string {
+	return s.Modifiers.String() + KeyName(s.Key)
+}
+
+// SymbolString returns a representation of the stroke that uses symbols for
+// the modifiers, e.g. "⌃C".
+func (s Stroke) SymbolString() string {
+	return s.Modifiers.SymbolString() + KeyName(s.Key)
+}
+
+// Matches returns true if the key code and modifiers trigger this stroke.
+func (s Stroke) Matches(keyCode int, mod keys.Modifiers) bool {
+	return s.Key.Code == keyCode && s.Modifiers&keys.NonStickyModifiers == mod&keys.NonStickyModifiers
+}
+
+// String returns the text representation of the binding, e.g. "Ctrl+K
+// Ctrl+C".
+func (b Binding) String() string {
+	parts := make([]string, len(b))
+	for i, s := range b {
+		parts[i] = s.String()
+	}
+	return strings.Join(parts, " ")
+}
+
+// SymbolString returns a representation of the binding that uses symbols for
+// the modifiers, e.g. "⌃K ⌃C".
+func (b Binding) SymbolString() string {
+	parts := make([]string, len(b))
+	for i, s := range b {
+		parts[i] = s.SymbolString()
+	}
+	return strings.Join(parts, " ")
+}
+
+// Equal returns true if the two bindings are made up of the same strokes.
+func (b Binding) Equal(other Binding) bool {
+	return len(b) == len(other) && b.HasPrefix(other)
+}
+
+// HasPrefix returns true if this binding starts with the strokes in prefix.
+func (b Binding) HasPrefix(prefix Binding) bool {
+	if len(prefix) > len(b) {
+		return false
+	}
+	for i, s := range prefix {
+		if !b[i].Matches(s.Key.Code, s.Modifiers) {
+			return false
+		}
+	}
+	return true
+}
+
+// ConflictsWith returns true if the two bindings can't both be in use at the
+// same time, because they are the same or one starts with the other.
+func (b Binding) ConflictsWith(other Binding) bool {
+	return b.HasPrefix(other) || other.HasPrefix(b)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (b Binding) MarshalText() (text []byte, err error) {
+	return []byte(b.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (b *Binding) UnmarshalText(text []byte) error {
+	parsed, err := ParseBinding(string(text))
+	if err != nil {
+		return err
+	}
+	*b = parsed
+	return nil
+}
@@ -0,0 +1,128 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package keybinding
+
+import (
+	"github.com/richardwilkes/ux"
+	"github.com/richardwilkes/ux/action"
+	"github.com/richardwilkes/ux/keys"
+)
+
+var (
+	// PendingChangedCallback, if set, is called whenever the strokes typed
+	// so far toward a multi-stroke binding change, including when they are
+	// abandoned. Use Pending() to retrieve them, for example to show them in
+	// a status bar.
+	PendingChangedCallback func()
+	pending                Binding
+	pendingWnd             *ux.Window
+	currentCapture         *capture
+)
+
+type capture struct {
+	wnd *ux.Window
+	f   func(s Stroke)
+}
+
+func init() {
+	ux.KeyBindingCallback = handleKeyDown
+}
+
+// Pending returns the strokes typed so far toward a multi-stroke binding.
+func Pending() Binding {
+	return append(Binding(nil), pending...)
+}
+
+// Capture redirects the key strokes typed in the window to the function,
+// rather than using them to trigger actions, until the returned function is
+// called. Presses of the modifier keys by themselves are not reported. This
+// is intended for use by editors that let the user type the binding they
+// want. Note that on macOS, key strokes used by menu items are handled by the
+// menu first.
+func Capture(wnd *ux.Window, f func(s Stroke)) (stop func()) {
+	setPending(nil, nil)
+	c := &capture{wnd: wnd, f: f}
+	currentCapture = c
+	return func() {
+		if currentCapture == c {
+			currentCapture = nil
+		}
+	}
+}
+
+// handleKeyDown is called twice for each key press: first before the focused
+// panel sees it and then, if the focused panel didn't consume it, after.
+// Strokes that have no modifiers other than Shift are normally used for
+// typing and editing, so they are only matched against the bindings on the
+// second call, unless they continue a pending binding. Everything else is
+// matched on the first call.
+func handleKeyDown(wnd *ux.Window, keyCode int, ch rune, mod keys.Modifiers, repeat, afterFocus bool) bool {
+	key := keys.ByCode[keyCode]
+	if key == nil {
+		return false
+	}
+	stroke := Stroke{Key: key, Modifiers: mod & keys.NonStickyModifiers}
+	if currentCapture != nil && currentCapture.wnd == wnd {
+		if !repeat {
+			currentCapture.f(stroke)
+		}
+		return true
+	}
+	hadPending := len(pending) != 0
+	if hadPending && pendingWnd != wnd {
+		setPending(nil, nil)
+		hadPending = false
+	}
+	if plain := !hadPending && stroke.Modifiers&^keys.ShiftModifier == 0; plain != afterFocus {
+		return false
+	}
+	if hadPending && repeat {
+		return true
+	}
+	seq := append(Pending(), stroke)
+	var exact action.Action
+	var isPrefix bool
+	for _, a := range action.All() {
+		for _, b := range Bindings(a) {
+			switch {
+			case b.Equal(seq):
+				if exact == nil {
+					exact = a
+				}
+			case b.HasPrefix(seq):
+				isPrefix = true
+			}
+		}
+	}
+	switch {
+	case exact != nil:
+		setPending(nil, nil)
+		return action.Perform(exact, wnd) || hadPending
+	case isPrefix && !repeat:
+		setPending(seq, wnd)
+		return true
+	default:
+		// A key that doesn't continue a pending binding abandons it and is
+		// swallowed.
+		setPending(nil, nil)
+		return hadPending
+	}
+}
+
+func setPending(seq Binding, wnd *ux.Window) {
+	if len(seq) == 0 && len(pending) == 0 {
+		return
+	}
+	pending = seq
+	pendingWnd = wnd
+	if PendingChangedCallback != nil {
+		PendingChangedCallback()
+	}
+}
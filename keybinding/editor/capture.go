@@ -0,0 +1,136 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package editor
+
+import (
+	"math"
+
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ux"
+	"github.com/richardwilkes/ux/border"
+	"github.com/richardwilkes/ux/draw"
+	"github.com/richardwilkes/ux/keybinding"
+	"github.com/richardwilkes/ux/keys"
+	"github.com/richardwilkes/ux/layout"
+)
+
+// maxStrokes is the number of strokes a recorded binding may have. Typing
+// another stroke once it has been reached starts a new binding.
+const maxStrokes = 2
+
+var (
+	focusedBorder   = border.NewCompound(border.NewLine(draw.ControlAccentColor, 0, geom.NewUniformInsets(2), false), border.NewEmpty(geom.Insets{Top: 1, Left: 2, Bottom: 0, Right: 2}))
+	unfocusedBorder = border.NewCompound(border.NewLine(draw.ControlEdgeAdjColor, 0, geom.NewUniformInsets(1), false), border.NewEmpty(geom.Insets{Top: 2, Left: 3, Bottom: 1, Right: 3}))
+)
+
+// captureField records the key binding typed by the user while it has the
+// keyboard focus.
+type captureField struct {
+	ux.Panel
+	ChangedCallback func()
+	binding         keybinding.Binding
+	stopCapture     func()
+}
+
+func newCaptureField() *captureField {
+	f := &captureField{}
+	f.InitTypeAndID(f)
+	f.SetFocusable(true)
+	f.SetBorder(unfocusedBorder)
+	f.SetSizer(f.sizes)
+	f.DrawCallback = f.draw
+	f.GainedFocusCallback = f.focusGained
+	f.LostFocusCallback = f.focusLost
+	f.MouseDownCallback = f.mouseDown
+	f.AddWindowExitHook(f.stop)
+	return f
+}
+
+func (f *captureField) sizes(hint geom.Size) (min, pref, max geom.Size) {
+	pref.Width = 200
+	pref.Height = math.Ceil(draw.UserFont.Height())
+	pref.AddInsets(f.Border().Insets())
+	pref.GrowToInteger()
+	min = pref
+	min.Width = 50
+	return min, pref, layout.MaxSize(pref)
+}
+
+func (f *captureField) draw(gc draw.Context, dirty geom.Rect, inLiveResize bool) {
+	gc.Rect(f.ContentRect(true))
+	gc.Fill(draw.TextBackgroundColor)
+	rect := f.ContentRect(false)
+	gc.Rect(rect)
+	gc.Clip()
+	text := f.binding.String()
+	ink := draw.Ink(draw.TextColor)
+	if len(f.binding) == 0 {
+		if f.Focused() {
+			text = i18n.Text("Type a key binding")
+		} else {
+			text = i18n.Text("Click to record a key binding")
+		}
+		ink = draw.PlaceholderTextColor
+	}
+	gc.DrawString(rect.X, rect.Y+(rect.Height-draw.UserFont.Height())/2, draw.UserFont, ink, text)
+}
+
+func (f *captureField) focusGained() {
+	f.SetBorder(focusedBorder)
+	if f.stopCapture == nil {
+		f.stopCapture = keybinding.Capture(f.Window(), f.record)
+	}
+	f.MarkForRedraw()
+}
+
+func (f *captureField) focusLost() {
+	f.SetBorder(unfocusedBorder)
+	f.stop()
+	f.MarkForRedraw()
+}
+
+func (f *captureField) stop() {
+	if f.stopCapture != nil {
+		f.stopCapture()
+		f.stopCapture = nil
+	}
+}
+
+func (f *captureField) mouseDown(where geom.Point, button, clickCount int, mod keys.Modifiers) bool {
+	if f.Focused() {
+		f.SetBinding(nil)
+	} else {
+		f.RequestFocus()
+	}
+	return true
+}
+
+func (f *captureField) record(s keybinding.Stroke) {
+	if len(f.binding) >= maxStrokes {
+		f.SetBinding(keybinding.Binding{s})
+	} else {
+		f.SetBinding(append(append(keybinding.Binding(nil), f.binding...), s))
+	}
+}
+
+// Binding returns the recorded key binding.
+func (f *captureField) Binding() keybinding.Binding {
+	return f.binding
+}
+
+// SetBinding sets the recorded key binding.
+func (f *captureField) SetBinding(b keybinding.Binding) {
+	f.binding = b
+	f.MarkForRedraw()
+	if f.ChangedCallback != nil {
+		f.ChangedCallback()
+	}
+}
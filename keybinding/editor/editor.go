@@ -0,0 +1,204 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+// Package editor provides a panel for viewing and changing the key bindings
+// of the registered actions, suitable for use in a preferences window.
+package editor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ux"
+	"github.com/richardwilkes/ux/action"
+	"github.com/richardwilkes/ux/draw"
+	"github.com/richardwilkes/ux/keybinding"
+	"github.com/richardwilkes/ux/layout/align"
+	"github.com/richardwilkes/ux/layout/flex"
+	"github.com/richardwilkes/ux/widget/button"
+	"github.com/richardwilkes/ux/widget/label"
+	"github.com/richardwilkes/ux/widget/list"
+	"github.com/richardwilkes/ux/widget/scrollarea"
+	"github.com/richardwilkes/ux/widget/scrollarea/behavior"
+)
+
+// Editor lists the registered actions along with their key bindings and
+// allows the user to change them. Changes take effect immediately. Use
+// keybinding.AddChangeListener() to find out when they should be saved.
+type Editor struct {
+	ux.Panel
+	actions        []action.Action
+	lst            *list.List
+	field          *captureField
+	message        *label.Label
+	addButton      *button.Button
+	clearButton    *button.Button
+	resetButton    *button.Button
+	removeListener func()
+}
+
+// New creates a new key binding editor.
+func New() *Editor {
+	e := &Editor{}
+	e.InitTypeAndID(e)
+	flex.New().VSpacing(4).Apply(e)
+
+	e.lst = list.New().SetFactory(&cellFactory{})
+	e.lst.NewSelectionCallback = e.adjust
+	scroller := scrollarea.New().SetContent(e.lst.AsPanel(), behavior.Fill)
+	flex.NewData().HAlign(align.Fill).VAlign(align.Fill).HGrab(true).VGrab(true).SizeHint(geom.Size{Width: 400, Height: 240}).Apply(scroller)
+	e.AddChild(scroller.AsPanel())
+
+	row := ux.NewPanel()
+	flex.New().Columns(2).HSpacing(4).Apply(row)
+	flex.NewData().HAlign(align.Fill).HGrab(true).Apply(row)
+	e.AddChild(row)
+	l := label.New().SetText(i18n.Text("Key Binding:"))
+	flex.NewData().VAlign(align.Middle).Apply(l)
+	row.AddChild(l.AsPanel())
+	e.field = newCaptureField()
+	e.field.ChangedCallback = e.adjust
+	flex.NewData().HAlign(align.Fill).HGrab(true).Apply(e.field)
+	row.AddChild(e.field.AsPanel())
+
+	e.message = label.New().SetInk(draw.SystemRedColor).SetFont(draw.SmallSystemFont)
+	flex.NewData().HAlign(align.Fill).HGrab(true).Apply(e.message)
+	e.AddChild(e.message.AsPanel())
+
+	buttons := ux.NewPanel()
+	flex.New().Columns(4).EqualColumns(true).HSpacing(4).Apply(buttons)
+	flex.NewData().HAlign(align.End).Apply(buttons)
+	e.AddChild(buttons)
+	e.addButton = e.addButtonTo(buttons, i18n.Text("Add"), e.add)
+	e.clearButton = e.addButtonTo(buttons, i18n.Text("Clear"), e.clear)
+	e.resetButton = e.addButtonTo(buttons, i18n.Text("Reset"), e.reset)
+	e.addButtonTo(buttons, i18n.Text("Reset All"), keybinding.ResetAll)
+
+	e.removeListener = keybinding.AddChangeListener(e.Refresh)
+	e.AddWindowExitHook(func() {
+		if e.removeListener != nil {
+			e.removeListener()
+			e.removeListener = nil
+		}
+	})
+	e.Refresh()
+	e.lst.Select(false, 0)
+	e.adjust()
+	return e
+}
+
+func (e *Editor) addButtonTo(panel *ux.Panel, title string, clickCallback func()) *button.Button {
+	b := button.New().SetText(title)
+	b.ClickCallback = clickCallback
+	flex.NewData().HAlign(align.Fill).Apply(b)
+	panel.AddChild(b.AsPanel())
+	return b
+}
+
+// Refresh reloads the list of actions and their key bindings.
+func (e *Editor) Refresh() {
+	selected := e.selectedAction()
+	for i := len(e.actions) - 1; i >= 0; i-- {
+		e.lst.Remove(i)
+	}
+	e.lst.Selection.Reset()
+	e.actions = action.All()
+	sort.SliceStable(e.actions, func(i, j int) bool {
+		return strings.ToLower(e.actions[i].Title()) < strings.ToLower(e.actions[j].Title())
+	})
+	for i, a := range e.actions {
+		e.lst.Append(a)
+		if selected != nil && a.ID() == selected.ID() {
+			e.lst.Select(false, i)
+		}
+	}
+	e.adjust()
+}
+
+func (e *Editor) selectedAction() action.Action {
+	if e.lst.Selection.Count() == 1 {
+		if index := e.lst.Selection.FirstSet(); index < len(e.actions) {
+			return e.actions[index]
+		}
+	}
+	return nil
+}
+
+// adjust updates the message and the enabled state of the buttons to match
+// the current selection and recorded key binding.
+func (e *Editor) adjust() {
+	a := e.selectedAction()
+	b := e.field.Binding()
+	var msg string
+	if a != nil && len(b) != 0 {
+		if conflicts := keybinding.Conflicts(a.ID(), b); len(conflicts) != 0 {
+			titles := make([]string, len(conflicts))
+			for i, one := range conflicts {
+				titles[i] = one.Title()
+			}
+			msg = fmt.Sprintf(i18n.Text("Conflicts with: %s"), strings.Join(titles, ", "))
+		}
+	}
+	e.message.SetText(msg)
+	e.addButton.SetEnabled(a != nil && len(b) != 0 && msg == "")
+	e.clearButton.SetEnabled(a != nil && len(keybinding.Bindings(a)) != 0)
+	e.resetButton.SetEnabled(a != nil && !keybinding.IsDefault(a))
+}
+
+func (e *Editor) add() {
+	if a := e.selectedAction(); a != nil {
+		if err := keybinding.SetBindings(a, append(keybinding.Bindings(a), e.field.Binding())...); err != nil {
+			e.message.SetText(err.Error())
+			return
+		}
+		e.field.SetBinding(nil)
+	}
+}
+
+func (e *Editor) clear() {
+	if a := e.selectedAction(); a != nil {
+		if err := keybinding.SetBindings(a); err != nil {
+			e.message.SetText(err.Error())
+		}
+	}
+}
+
+func (e *Editor) reset() {
+	if a := e.selectedAction(); a != nil {
+		keybinding.Reset(a)
+	}
+}
+
+type cellFactory struct{}
+
+// CellHeight implements widget.CellFactory.
+func (f *cellFactory) CellHeight() float64 {
+	return action.CellHeight()
+}
+
+// CreateCell implements widget.CellFactory.
+func (f *cellFactory) CreateCell(owner *ux.Panel, element interface{}, index int, selected, focused bool) *ux.Panel {
+	a, ok := element.(action.Action)
+	if !ok {
+		return ux.NewPanel()
+	}
+	bindings := keybinding.Bindings(a)
+	parts := make([]string, len(bindings))
+	for i, b := range bindings {
+		parts[i] = b.String()
+	}
+	var ink draw.Ink
+	if !keybinding.IsDefault(a) {
+		ink = draw.ControlAccentColor
+	}
+	return action.NewCell(a, strings.Join(parts, ", "), ink, selected)
+}
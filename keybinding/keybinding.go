@@ -0,0 +1,285 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+// Package keybinding maps the actions in the action registry to the key
+// bindings that trigger them. Each action starts out with its compiled-in hot
+// key, if any, which may then be replaced at runtime by the user. Only the
+// bindings that differ from the defaults are saved.
+package keybinding
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/xio/fs"
+	"github.com/richardwilkes/ux/action"
+	"github.com/richardwilkes/ux/keys"
+)
+
+const currentVersion = 1
+
+var (
+	overrides       = make(map[int][]Binding)
+	changeListeners []*changeListener
+)
+
+type changeListener struct {
+	f func()
+}
+
+type fileData struct {
+	Version  int               `json:"version"`
+	Bindings map[int][]Binding `json:"bindings"`
+}
+
+// Bindings returns the key bindings currently in effect for the action.
+func Bindings(a action.Action) []Binding {
+	if list, exists := overrides[a.ID()]; exists {
+		return copyBindings(list)
+	}
+	return DefaultBindings(a)
+}
+
+// DefaultBindings returns the key bindings the action has when none have been
+// set for it, which is its hot key, if any.
+func DefaultBindings(a action.Action) []Binding {
+	if key := a.HotKey(); key != nil {
+		return []Binding{NewBinding(key, a.HotKeyModifiers())}
+	}
+	return nil
+}
+
+// IsDefault returns true if the action is using its default key bindings.
+func IsDefault(a action.Action) bool {
+	_, exists := overrides[a.ID()]
+	return !exists
+}
+
+// HotKey returns the key and modifiers of the first single stroke binding in
+// effect for the action, which is what menu items display. Returns nil if
+// the action has no single stroke binding.
+func HotKey(a action.Action) (key *keys.Key, mod keys.Modifiers) {
+	for _, b := range Bindings(a) {
+		if len(b) == 1 {
+			return b[0].Key, b[0].Modifiers
+		}
+	}
+	return nil, 0
+}
+
+// ActionFor returns the registered action that the binding triggers, or nil.
+func ActionFor(b Binding) action.Action {
+	for _, a := range action.All() {
+		for _, one := range Bindings(a) {
+			if one.Equal(b) {
+				return a
+			}
+		}
+	}
+	return nil
+}
+
+// Conflicts returns the registered actions, other than the one with the ID,
+// that have a key binding that conflicts with the binding.
+func Conflicts(id int, b Binding) []action.Action {
+	var list []action.Action
+	for _, a := range action.All() {
+		if a.ID() == id {
+			continue
+		}
+		for _, one := range Bindings(a) {
+			if one.ConflictsWith(b) {
+				list = append(list, a)
+				break
+			}
+		}
+	}
+	return list
+}
+
+// SetBindings replaces the key bindings for the action. Pass no bindings to
+// remove all of them. An error is returned and no change is made if any of
+// the bindings conflict with each other or with those of another registered
+// action.
+func SetBindings(a action.Action, bindings ...Binding) error {
+	for i, b := range bindings {
+		if len(b) == 0 {
+			return errs.New("empty key binding")
+		}
+		for _, other := range bindings[:i] {
+			if b.ConflictsWith(other) {
+				return errs.Newf("key binding '%s' conflicts with '%s'", b, other)
+			}
+		}
+		if list := Conflicts(a.ID(), b); len(list) != 0 {
+			titles := make([]string, len(list))
+			for j, one := range list {
+				titles[j] = one.Title()
+			}
+			return errs.Newf("key binding '%s' conflicts with %s", b, strings.Join(titles, ", "))
+		}
+	}
+	if bindingsEqual(bindings, DefaultBindings(a)) {
+		delete(overrides, a.ID())
+	} else {
+		overrides[a.ID()] = copyBindings(bindings)
+	}
+	notifyOfChange()
+	return nil
+}
+
+// Reset restores the default key bindings for the action.
+func Reset(a action.Action) {
+	if _, exists := overrides[a.ID()]; exists {
+		delete(overrides, a.ID())
+		notifyOfChange()
+	}
+}
+
+// ResetAll restores the default key bindings for all actions.
+func ResetAll() {
+	if len(overrides) != 0 {
+		overrides = make(map[int][]Binding)
+		notifyOfChange()
+	}
+}
+
+// Load the key bindings from a JSON file previously written by Save. The
+// loaded bindings replace any that had been set previously. An error is
+// returned and no change is made if any of the loaded bindings conflict with
+// each other or with the bindings of the registered actions.
+func Load(path string) error {
+	var data fileData
+	if err := fs.LoadJSON(path, &data); err != nil {
+		return err
+	}
+	if data.Version > currentVersion {
+		return errs.Newf("key bindings file '%s' has an unsupported version (%d)", path, data.Version)
+	}
+	loaded := make(map[int][]Binding, len(data.Bindings))
+	for id, list := range data.Bindings {
+		loaded[id] = copyBindings(list)
+	}
+	if err := validate(loaded); err != nil {
+		return errs.NewWithCause(fmt.Sprintf("key bindings file '%s' is invalid", path), err)
+	}
+	overrides = loaded
+	notifyOfChange()
+	return nil
+}
+
+// validate checks that none of the bindings that would be in effect with the
+// overrides conflict with each other.
+func validate(candidate map[int][]Binding) error {
+	type entry struct {
+		id      int
+		binding Binding
+	}
+	var entries []entry
+	add := func(id int, bindings []Binding) error {
+		for _, b := range bindings {
+			if len(b) == 0 {
+				return errs.Newf("empty key binding for %s", titleFor(id))
+			}
+			for _, other := range entries {
+				if b.ConflictsWith(other.binding) {
+					return errs.Newf("key binding '%s' for %s conflicts with '%s' for %s", b, titleFor(id), other.binding, titleFor(other.id))
+				}
+			}
+			entries = append(entries, entry{id: id, binding: b})
+		}
+		return nil
+	}
+	for _, a := range action.All() {
+		bindings, exists := candidate[a.ID()]
+		if !exists {
+			bindings = DefaultBindings(a)
+		}
+		if err := add(a.ID(), bindings); err != nil {
+			return err
+		}
+	}
+	unregistered := make([]int, 0, len(candidate))
+	for id := range candidate {
+		if action.Lookup(id) == nil {
+			unregistered = append(unregistered, id)
+		}
+	}
+	sort.Ints(unregistered)
+	for _, id := range unregistered {
+		if err := add(id, candidate[id]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func titleFor(id int) string {
+	if a := action.Lookup(id); a != nil {
+		return a.Title()
+	}
+	return fmt.Sprintf("action %d", id)
+}
+
+// Save the key bindings that differ from the defaults to a JSON file.
+func Save(path string) error {
+	return fs.SaveJSON(path, &fileData{
+		Version:  currentVersion,
+		Bindings: overrides,
+	}, true)
+}
+
+// AddChangeListener adds a function to be called whenever the key bindings
+// change. Call the returned function to remove the listener.
+func AddChangeListener(f func()) (remove func()) {
+	listener := &changeListener{f: f}
+	changeListeners = append(changeListeners, listener)
+	return func() {
+		for i, one := range changeListeners {
+			if one == listener {
+				copy(changeListeners[i:], changeListeners[i+1:])
+				changeListeners[len(changeListeners)-1] = nil
+				changeListeners = changeListeners[:len(changeListeners)-1]
+				break
+			}
+		}
+	}
+}
+
+func notifyOfChange() {
+	if len(changeListeners) != 0 {
+		listeners := make([]*changeListener, len(changeListeners))
+		copy(listeners, changeListeners)
+		for _, listener := range listeners {
+			listener.f()
+		}
+	}
+}
+
+func copyBindings(bindings []Binding) []Binding {
+	list := make([]Binding, len(bindings))
+	for i, b := range bindings {
+		list[i] = append(Binding(nil), b...)
+	}
+	return list
+}
+
+func bindingsEqual(a, b []Binding) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,120 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package keybinding_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/richardwilkes/ux/action"
+	"github.com/richardwilkes/ux/ids"
+	"github.com/richardwilkes/ux/keybinding"
+	"github.com/richardwilkes/ux/keys"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBinding(t *testing.T) {
+	b, err := keybinding.ParseBinding("Ctrl+Shift+P")
+	require.NoError(t, err)
+	assert.True(t, b.Equal(keybinding.NewBinding(keys.P, keys.ControlModifier|keys.ShiftModifier)))
+	assert.Equal(t, "Ctrl+Shift+P", b.String())
+
+	b, err = keybinding.ParseBinding("ctrl+k  CONTROL+c")
+	require.NoError(t, err)
+	assert.Len(t, b, 2)
+	assert.Equal(t, "Ctrl+K Ctrl+C", b.String())
+
+	b, err = keybinding.ParseBinding("Numpad1")
+	require.NoError(t, err)
+	assert.Equal(t, keys.Numpad1, b[0].Key)
+	b, err = keybinding.ParseBinding("1")
+	require.NoError(t, err)
+	assert.Equal(t, keys.One, b[0].Key)
+
+	_, err = keybinding.ParseBinding("")
+	assert.Error(t, err)
+	_, err = keybinding.ParseBinding("Ctrl+Bogus")
+	assert.Error(t, err)
+}
+
+func TestConflicts(t *testing.T) {
+	chord, err := keybinding.ParseBinding("Ctrl+K Ctrl+C")
+	require.NoError(t, err)
+	prefix := keybinding.NewBinding(keys.K, keys.ControlModifier)
+	other := keybinding.NewBinding(keys.K, keys.ControlModifier|keys.ShiftModifier)
+	assert.True(t, chord.HasPrefix(prefix))
+	assert.False(t, prefix.HasPrefix(chord))
+	assert.True(t, chord.ConflictsWith(prefix))
+	assert.True(t, prefix.ConflictsWith(chord))
+	assert.True(t, chord.ConflictsWith(chord))
+	assert.False(t, chord.ConflictsWith(other))
+}
+
+func TestSetBindings(t *testing.T) {
+	one := &action.CmdAction{ActionID: ids.UserBaseID + 1, ActionTitle: "One", ActionHotKey: keys.F5}
+	two := &action.CmdAction{ActionID: ids.UserBaseID + 2, ActionTitle: "Two"}
+	action.Register(one, two)
+	defer func() {
+		action.Unregister(one.ID())
+		action.Unregister(two.ID())
+		keybinding.ResetAll()
+	}()
+
+	assert.True(t, keybinding.IsDefault(one))
+	key, mod := keybinding.HotKey(one)
+	assert.Equal(t, keys.F5, key)
+	assert.Equal(t, keys.Modifiers(0), mod)
+
+	chord, err := keybinding.ParseBinding("Ctrl+K Ctrl+C")
+	require.NoError(t, err)
+	require.NoError(t, keybinding.SetBindings(two, chord))
+	assert.Equal(t, two, keybinding.ActionFor(chord))
+	key, _ = keybinding.HotKey(two)
+	assert.Nil(t, key)
+
+	prefix := keybinding.NewBinding(keys.K, keys.ControlModifier)
+	assert.Equal(t, []action.Action{two}, keybinding.Conflicts(one.ID(), prefix))
+	assert.Error(t, keybinding.SetBindings(one, prefix))
+	assert.True(t, keybinding.IsDefault(one))
+
+	require.NoError(t, keybinding.SetBindings(one))
+	assert.False(t, keybinding.IsDefault(one))
+	assert.Empty(t, keybinding.Bindings(one))
+
+	dir, err := ioutil.TempDir("", "keybinding_test")
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, os.RemoveAll(dir)) }()
+	path := filepath.Join(dir, "keybindings.json")
+	require.NoError(t, keybinding.Save(path))
+	keybinding.ResetAll()
+	assert.True(t, keybinding.IsDefault(one))
+	assert.True(t, keybinding.IsDefault(two))
+	require.NoError(t, keybinding.Load(path))
+	assert.Empty(t, keybinding.Bindings(one))
+	assert.Equal(t, two, keybinding.ActionFor(chord))
+
+	// A hand-edited file with conflicting bindings is rejected.
+	require.NoError(t, ioutil.WriteFile(path, []byte(fmt.Sprintf(`{"version":1,"bindings":{"%d":["Ctrl+K"],"%d":["Ctrl+K Ctrl+C"]}}`, one.ID(), two.ID())), 0600))
+	assert.Error(t, keybinding.Load(path))
+	assert.Empty(t, keybinding.Bindings(one))
+	require.NoError(t, ioutil.WriteFile(path, []byte(fmt.Sprintf(`{"version":1,"bindings":{"%d":["F6"],"%d":["F6"]}}`, one.ID(), ids.UserBaseID+99)), 0600))
+	assert.Error(t, keybinding.Load(path))
+	assert.Empty(t, keybinding.Bindings(one))
+
+	keybinding.Reset(one)
+	assert.True(t, keybinding.IsDefault(one))
+	require.NoError(t, keybinding.SetBindings(two))
+	require.NoError(t, keybinding.SetBindings(two, keybinding.DefaultBindings(two)...))
+	assert.True(t, keybinding.IsDefault(two))
+}
@@ -104,6 +104,7 @@ var ByCode = map[int]*Key{
 	Numpad8.Code:        Numpad8,
 	Numpad9.Code:        Numpad9,
 	Numpad0.Code:        Numpad0,
+	ContextMenu.Code:    ContextMenu,
 }
 
 // Some common aliases
@@ -104,4 +104,5 @@ var (
 	Numpad8        = &Key{Code: 0x5b, Name: "8"}
 	Numpad9        = &Key{Code: 0x5c, Name: "9"}
 	Numpad0        = &Key{Code: 0x52, Name: "0"}
+	ContextMenu    = &Key{Code: 0x6e, Name: "Menu"}
 )
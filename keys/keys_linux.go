@@ -104,4 +104,5 @@ var (
 	Numpad8        = &Key{Code: 0x50, Name: "8"}
 	Numpad9        = &Key{Code: 0x51, Name: "9"}
 	Numpad0        = &Key{Code: 0x5a, Name: "0"}
+	ContextMenu    = &Key{Code: 0x87, Name: "Menu"}
 )
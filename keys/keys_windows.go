@@ -104,4 +104,5 @@ var (
 	Numpad8        = &Key{Code: 0x68, Name: "8"}
 	Numpad9        = &Key{Code: 0x69, Name: "9"}
 	Numpad0        = &Key{Code: 0x60, Name: "0"}
+	ContextMenu    = &Key{Code: 0x5d, Name: "Menu"}
 )
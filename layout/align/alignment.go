@@ -19,3 +19,19 @@ const (
 
 // Alignment specifies how to align an object within its available space.
 type Alignment uint8
+
+// String implements fmt.Stringer.
+func (a Alignment) String() string {
+	switch a {
+	case Start:
+		return "start"
+	case Middle:
+		return "middle"
+	case End:
+		return "end"
+	case Fill:
+		return "fill"
+	default:
+		return "unknown"
+	}
+}
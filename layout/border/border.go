@@ -0,0 +1,228 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package border
+
+import (
+	"math"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ux/layout"
+	"github.com/richardwilkes/ux/layout/side"
+)
+
+// Border holds the border layout information.
+type Border struct {
+	target   layout.Layoutable
+	hSpacing float64
+	vSpacing float64
+}
+
+type regions struct {
+	top    []layout.Layoutable
+	bottom []layout.Layoutable
+	left   []layout.Layoutable
+	right  []layout.Layoutable
+	center []layout.Layoutable
+}
+
+// New creates a new Border layout. This layout places each child of its
+// target along one of the target's edges, as specified by a side.Side set
+// for the child's LayoutData. Top and bottom children span the full width of
+// the target, while left and right children fill the height remaining
+// between them. Multiple children on the same side are stacked from the edge
+// inward in the order they were added. Children without a side.Side are
+// placed in the center, which absorbs any extra space.
+func New() *Border {
+	return &Border{
+		hSpacing: layout.DefaultHSpacing,
+		vSpacing: layout.DefaultVSpacing,
+	}
+}
+
+// HSpacing sets the spacing between horizontally adjacent regions. Defaults
+// to DefaultHSpacing.
+func (b *Border) HSpacing(hSpacing float64) *Border {
+	b.hSpacing = hSpacing
+	return b
+}
+
+// VSpacing sets the spacing between vertically adjacent regions. Defaults to
+// DefaultVSpacing.
+func (b *Border) VSpacing(vSpacing float64) *Border {
+	b.vSpacing = vSpacing
+	return b
+}
+
+// Apply the layout to the target. A copy is made of this layout and that is
+// applied to the target, so this layout may be applied to other targets.
+func (b *Border) Apply(target layout.Layoutable) {
+	border := *b
+	border.target = target
+	target.SetLayout(&border)
+}
+
+func (b *Border) regions() *regions {
+	r := &regions{}
+	for _, child := range b.target.ChildrenForLayout() {
+		s, ok := child.LayoutData().(side.Side)
+		switch {
+		case !ok:
+			r.center = append(r.center, child)
+		case s == side.Top:
+			r.top = append(r.top, child)
+		case s == side.Bottom:
+			r.bottom = append(r.bottom, child)
+		case s == side.Left:
+			r.left = append(r.left, child)
+		case s == side.Right:
+			r.right = append(r.right, child)
+		default:
+			r.center = append(r.center, child)
+		}
+	}
+	return r
+}
+
+func (b *Border) insets() geom.Insets {
+	if border := b.target.Border(); border != nil {
+		return border.Insets()
+	}
+	return geom.Insets{}
+}
+
+// Sizes implements Layout.
+func (b *Border) Sizes(hint geom.Size) (min, pref, max geom.Size) {
+	if b.hSpacing < 0 {
+		b.hSpacing = 0
+	}
+	if b.vSpacing < 0 {
+		b.vSpacing = 0
+	}
+	insets := b.insets()
+	if hint.Width > 0 {
+		hint.Width = math.Max(hint.Width-(insets.Left+insets.Right), 0)
+	}
+	if hint.Height > 0 {
+		hint.Height = math.Max(hint.Height-(insets.Top+insets.Bottom), 0)
+	}
+	r := b.regions()
+	var sizes [3]struct {
+		width     float64
+		height    float64
+		midWidth  float64
+		midHeight float64
+	}
+	accumulate := func(children []layout.Layoutable, childHint geom.Size, horizontal bool) {
+		for _, child := range children {
+			cMin, cPref, cMax := child.Sizes(childHint)
+			for i, size := range []geom.Size{cMin, cPref, cMax} {
+				if horizontal {
+					sizes[i].midWidth += size.Width
+					sizes[i].midHeight = math.Max(sizes[i].midHeight, size.Height)
+				} else {
+					sizes[i].width = math.Max(sizes[i].width, size.Width)
+					sizes[i].height += size.Height
+				}
+			}
+		}
+	}
+	accumulate(r.top, geom.Size{Width: hint.Width}, false)
+	accumulate(r.bottom, geom.Size{Width: hint.Width}, false)
+	accumulate(r.left, geom.Size{}, true)
+	accumulate(r.right, geom.Size{}, true)
+	var centerMax geom.Size
+	for _, child := range r.center {
+		cMin, cPref, cMax := child.Sizes(geom.Size{})
+		for i, size := range []geom.Size{cMin, cPref, cMax} {
+			sizes[i].midHeight = math.Max(sizes[i].midHeight, size.Height)
+		}
+		sizes[0].midWidth = math.Max(sizes[0].midWidth, cMin.Width)
+		sizes[1].midWidth = math.Max(sizes[1].midWidth, cPref.Width)
+		centerMax.Width = math.Max(centerMax.Width, cMax.Width)
+	}
+	middleCount := len(r.left) + len(r.right)
+	if len(r.center) != 0 {
+		middleCount++
+		sizes[2].midWidth += centerMax.Width
+	}
+	bandCount := len(r.top) + len(r.bottom)
+	if middleCount != 0 {
+		bandCount++
+	}
+	var results [3]geom.Size
+	for i := range sizes {
+		s := &sizes[i]
+		if middleCount > 1 {
+			s.midWidth += b.hSpacing * float64(middleCount-1)
+		}
+		results[i].Width = math.Max(s.width, s.midWidth)
+		results[i].Height = s.height + s.midHeight
+		if bandCount > 1 {
+			results[i].Height += b.vSpacing * float64(bandCount-1)
+		}
+		results[i].AddInsets(insets)
+	}
+	min = results[0]
+	pref = results[1]
+	max = results[2]
+	if len(r.center) == 0 {
+		max = pref
+	}
+	if max.Width < pref.Width {
+		max.Width = pref.Width
+	}
+	if max.Height < pref.Height {
+		max.Height = pref.Height
+	}
+	return min, pref, layout.MaxSize(max)
+}
+
+// Layout implements Layout.
+func (b *Border) Layout() {
+	insets := b.insets()
+	rect := geom.Rect{Size: b.target.FrameRect().Size}
+	rect.X = insets.Left
+	rect.Y = insets.Top
+	rect.Width = math.Max(rect.Width-(insets.Left+insets.Right), 0)
+	rect.Height = math.Max(rect.Height-(insets.Top+insets.Bottom), 0)
+	r := b.regions()
+	mirror := layout.MirrorFor(b.target)
+	for _, child := range r.top {
+		_, pref, _ := child.Sizes(geom.Size{Width: rect.Width})
+		height := math.Min(pref.Height, rect.Height)
+		child.SetFrameRect(mirror.Rect(geom.Rect{Point: rect.Point, Size: geom.Size{Width: rect.Width, Height: height}}))
+		height = math.Min(height+b.vSpacing, rect.Height)
+		rect.Y += height
+		rect.Height -= height
+	}
+	for _, child := range r.bottom {
+		_, pref, _ := child.Sizes(geom.Size{Width: rect.Width})
+		height := math.Min(pref.Height, rect.Height)
+		child.SetFrameRect(mirror.Rect(geom.Rect{Point: geom.Point{X: rect.X, Y: rect.Y + rect.Height - height}, Size: geom.Size{Width: rect.Width, Height: height}}))
+		rect.Height -= math.Min(height+b.vSpacing, rect.Height)
+	}
+	for _, child := range r.left {
+		_, pref, _ := child.Sizes(geom.Size{Height: rect.Height})
+		width := math.Min(pref.Width, rect.Width)
+		child.SetFrameRect(mirror.Rect(geom.Rect{Point: rect.Point, Size: geom.Size{Width: width, Height: rect.Height}}))
+		width = math.Min(width+b.hSpacing, rect.Width)
+		rect.X += width
+		rect.Width -= width
+	}
+	for _, child := range r.right {
+		_, pref, _ := child.Sizes(geom.Size{Height: rect.Height})
+		width := math.Min(pref.Width, rect.Width)
+		child.SetFrameRect(mirror.Rect(geom.Rect{Point: geom.Point{X: rect.X + rect.Width - width, Y: rect.Y}, Size: geom.Size{Width: width, Height: rect.Height}}))
+		rect.Width -= math.Min(width+b.hSpacing, rect.Width)
+	}
+	for _, child := range r.center {
+		child.SetFrameRect(mirror.Rect(rect))
+	}
+}
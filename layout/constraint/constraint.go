@@ -0,0 +1,540 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package constraint
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ux/layout"
+)
+
+// Attribute constants.
+const (
+	Left Attribute = iota
+	Right
+	Top
+	Bottom
+	Width
+	Height
+	CenterX
+	CenterY
+)
+
+// Attribute specifies an edge, center or size of an item.
+type Attribute uint8
+
+// Relation constants.
+const (
+	Equal Relation = iota
+	LessThanOrEqual
+	GreaterThanOrEqual
+)
+
+// Relation specifies how the two sides of a constraint relate to each other.
+type Relation uint8
+
+// Priority constants. Constraints with a priority less than Required may be
+// violated if they conflict with higher priority constraints.
+const (
+	Required Priority = 1001001000
+	Strong   Priority = 1000000
+	Medium   Priority = 1000
+	Weak     Priority = 1
+)
+
+// Priority specifies how important it is that a constraint be satisfied.
+type Priority float64
+
+// Constraint describes a linear relationship between an attribute of an item
+// and either a constant or an attribute of another item, in the form:
+//
+//	item.attribute (relation) other.attribute * multiplier + constant
+type Constraint struct {
+	item       layout.Layoutable
+	attr       Attribute
+	relation   Relation
+	other      layout.Layoutable
+	otherAttr  Attribute
+	hasOther   bool
+	multiplier float64
+	constant   float64
+	priority   Priority
+}
+
+// Constraints holds the constraint layout information.
+type Constraints struct {
+	target      layout.Layoutable
+	constraints []*Constraint
+	references  map[layout.Layoutable]bool
+	names       map[layout.Layoutable]string
+	err         error
+}
+
+type itemVars struct {
+	x, y, w, h *variable
+}
+
+type solution struct {
+	constraints *Constraints
+	solver      *solver
+	items       map[layout.Layoutable]*itemVars
+	order       []layout.Layoutable
+	target      *itemVars
+	err         error
+}
+
+// Make starts a new constraint for the attribute of the item. Pass nil for
+// the item to refer to the target of the layout. The same value must be used
+// to refer to an item in every constraint. Complete the constraint by calling
+// one of the relation methods, such as EqualTo().
+func Make(item layout.Layoutable, attr Attribute) *Constraint {
+	return &Constraint{
+		item:       item,
+		attr:       attr,
+		multiplier: 1,
+		priority:   Required,
+	}
+}
+
+// EqualTo relates the constraint's attribute to the attribute of the other
+// item. Pass nil for the other item to refer to the target of the layout.
+func (c *Constraint) EqualTo(other layout.Layoutable, attr Attribute) *Constraint {
+	return c.relateTo(Equal, other, attr)
+}
+
+// LessThanOrEqualTo relates the constraint's attribute to the attribute of
+// the other item. Pass nil for the other item to refer to the target of the
+// layout.
+func (c *Constraint) LessThanOrEqualTo(other layout.Layoutable, attr Attribute) *Constraint {
+	return c.relateTo(LessThanOrEqual, other, attr)
+}
+
+// GreaterThanOrEqualTo relates the constraint's attribute to the attribute of
+// the other item. Pass nil for the other item to refer to the target of the
+// layout.
+func (c *Constraint) GreaterThanOrEqualTo(other layout.Layoutable, attr Attribute) *Constraint {
+	return c.relateTo(GreaterThanOrEqual, other, attr)
+}
+
+// EqualToConstant relates the constraint's attribute to a constant.
+func (c *Constraint) EqualToConstant(value float64) *Constraint {
+	return c.relateToConstant(Equal, value)
+}
+
+// LessThanOrEqualToConstant relates the constraint's attribute to a
+// constant.
+func (c *Constraint) LessThanOrEqualToConstant(value float64) *Constraint {
+	return c.relateToConstant(LessThanOrEqual, value)
+}
+
+// GreaterThanOrEqualToConstant relates the constraint's attribute to a
+// constant.
+func (c *Constraint) GreaterThanOrEqualToConstant(value float64) *Constraint {
+	return c.relateToConstant(GreaterThanOrEqual, value)
+}
+
+func (c *Constraint) relateTo(relation Relation, other layout.Layoutable, attr Attribute) *Constraint {
+	c.relation = relation
+	c.other = other
+	c.otherAttr = attr
+	c.hasOther = true
+	return c
+}
+
+func (c *Constraint) relateToConstant(relation Relation, value float64) *Constraint {
+	c.relation = relation
+	c.other = nil
+	c.hasOther = false
+	c.constant = value
+	return c
+}
+
+// Times sets the multiplier applied to the other item's attribute. Defaults
+// to 1.
+func (c *Constraint) Times(multiplier float64) *Constraint {
+	c.multiplier = multiplier
+	return c
+}
+
+// Plus sets the constant added to the other item's attribute. Defaults to 0.
+func (c *Constraint) Plus(constant float64) *Constraint {
+	c.constant = constant
+	return c
+}
+
+// WithPriority sets the priority of the constraint. Defaults to Required.
+func (c *Constraint) WithPriority(priority Priority) *Constraint {
+	if priority > Required {
+		priority = Required
+	} else if priority < 0 {
+		priority = 0
+	}
+	c.priority = priority
+	return c
+}
+
+// New creates a new Constraints layout. This layout positions and sizes the
+// items referenced by its constraints by solving for values that satisfy all
+// of the required constraints and as many of the others as their priorities
+// permit. Beyond the constraints explicitly added, each item is given a
+// strong preference to stay within its minimum and maximum sizes and within
+// the target, along with a medium preference for its preferred size.
+//
+// Items that are not children of the target may be referenced by calling
+// Reference(). Their positions are read rather than set.
+func New() *Constraints {
+	return &Constraints{}
+}
+
+// Add constraints.
+func (c *Constraints) Add(constraints ...*Constraint) *Constraints {
+	c.constraints = append(c.constraints, constraints...)
+	return c
+}
+
+// Reference marks the item as one that lives in another container. Its
+// current frame is converted into the target's coordinate system and treated
+// as fixed, which allows items in the target to be aligned with it. Both the
+// item and the target must provide a PointToRoot() method, as ux.Panel does,
+// for the conversion to occur.
+func (c *Constraints) Reference(item layout.Layoutable) *Constraints {
+	if c.references == nil {
+		c.references = make(map[layout.Layoutable]bool)
+	}
+	c.references[item] = true
+	return c
+}
+
+// Name sets the name used to refer to the item in error messages.
+func (c *Constraints) Name(item layout.Layoutable, name string) *Constraints {
+	if c.names == nil {
+		c.names = make(map[layout.Layoutable]string)
+	}
+	c.names[item] = name
+	return c
+}
+
+// Apply the layout to the target. A copy is made of this layout and that is
+// applied to the target, so this layout may be applied to other targets.
+func (c *Constraints) Apply(target layout.Layoutable) {
+	cs := *c
+	cs.target = target
+	cs.constraints = append([]*Constraint(nil), c.constraints...)
+	if c.references != nil {
+		cs.references = make(map[layout.Layoutable]bool, len(c.references))
+		for k, v := range c.references {
+			cs.references[k] = v
+		}
+	}
+	if c.names != nil {
+		cs.names = make(map[layout.Layoutable]string, len(c.names))
+		for k, v := range c.names {
+			cs.names[k] = v
+		}
+	}
+	target.SetLayout(&cs)
+}
+
+// Err returns an error describing the constraints that could not be
+// satisfied during the most recent sizing or layout, if any.
+func (c *Constraints) Err() error {
+	return c.err
+}
+
+// Check solves the constraints using the target's current size and returns
+// an error describing any that could not be satisfied.
+func (c *Constraints) Check() error {
+	insets := c.insets()
+	size := c.target.FrameRect().Size
+	size.Width -= insets.Left + insets.Right
+	size.Height -= insets.Top + insets.Bottom
+	return c.solve(size, true, true, false).err
+}
+
+// Sizes implements Layout.
+func (c *Constraints) Sizes(hint geom.Size) (min, pref, max geom.Size) {
+	insets := c.insets()
+	size := geom.Size{Width: -1, Height: -1}
+	if hint.Width > 0 {
+		size.Width = math.Max(hint.Width-(insets.Left+insets.Right), 0)
+	}
+	if hint.Height > 0 {
+		size.Height = math.Max(hint.Height-(insets.Top+insets.Bottom), 0)
+	}
+	s := c.solve(size, size.Width >= 0, size.Height >= 0, true)
+	min = geom.Size{Width: s.target.w.value, Height: s.target.h.value}
+	s = c.solve(size, size.Width >= 0, size.Height >= 0, false)
+	pref = geom.Size{Width: s.target.w.value, Height: s.target.h.value}
+	c.err = s.err
+	min.AddInsets(insets)
+	pref.AddInsets(insets)
+	if min.Width > pref.Width {
+		min.Width = pref.Width
+	}
+	if min.Height > pref.Height {
+		min.Height = pref.Height
+	}
+	return min, pref, layout.MaxSize(pref)
+}
+
+// Layout implements Layout.
+func (c *Constraints) Layout() {
+	insets := c.insets()
+	size := c.target.FrameRect().Size
+	size.Width = math.Max(size.Width-(insets.Left+insets.Right), 0)
+	size.Height = math.Max(size.Height-(insets.Top+insets.Bottom), 0)
+	s := c.solve(size, true, true, false)
+	c.err = s.err
+	for _, item := range s.order {
+		if !c.references[item] {
+			v := s.items[item]
+			item.SetFrameRect(geom.Rect{
+				Point: geom.Point{X: v.x.value, Y: v.y.value},
+				Size:  geom.Size{Width: math.Max(v.w.value, 0), Height: math.Max(v.h.value, 0)},
+			})
+		}
+	}
+}
+
+func (c *Constraints) insets() geom.Insets {
+	if border := c.target.Border(); border != nil {
+		return border.Insets()
+	}
+	return geom.Insets{}
+}
+
+func (c *Constraints) solve(size geom.Size, fixedWidth, fixedHeight, useMin bool) *solution {
+	s := &solution{
+		constraints: c,
+		solver:      newSolver(),
+		items:       make(map[layout.Layoutable]*itemVars),
+	}
+	insets := c.insets()
+	s.target = newItemVars()
+	s.require(s.target.x, Equal, insets.Left)
+	s.require(s.target.y, Equal, insets.Top)
+	s.require(s.target.w, GreaterThanOrEqual, 0)
+	s.require(s.target.h, GreaterThanOrEqual, 0)
+	if fixedWidth {
+		s.require(s.target.w, Equal, size.Width)
+	} else {
+		s.prefer(s.target.w, Equal, 0, Weak)
+	}
+	if fixedHeight {
+		s.require(s.target.h, Equal, size.Height)
+	} else {
+		s.prefer(s.target.h, Equal, 0, Weak)
+	}
+	for _, cn := range c.constraints {
+		if cn.item != nil {
+			s.addItem(cn.item, useMin)
+		}
+		if cn.hasOther && cn.other != nil {
+			s.addItem(cn.other, useMin)
+		}
+	}
+	for _, cn := range c.constraints {
+		expr := newExpression(0).addExpression(s.attrExpr(cn.item, cn.attr), 1)
+		if cn.hasOther {
+			expr.addExpression(s.attrExpr(cn.other, cn.otherAttr), -cn.multiplier)
+		}
+		expr.constant -= cn.constant
+		if err := s.solver.add(&equation{expr: expr, relation: cn.relation, strength: float64(cn.priority)}); err != nil {
+			s.err = errs.Append(s.err, errs.Newf("unable to satisfy constraint: %s", c.describe(cn)))
+		}
+	}
+	s.solver.updateVariables()
+	return s
+}
+
+func newItemVars() *itemVars {
+	return &itemVars{x: &variable{}, y: &variable{}, w: &variable{}, h: &variable{}}
+}
+
+func (s *solution) addItem(item layout.Layoutable, useMin bool) {
+	if _, exists := s.items[item]; exists {
+		return
+	}
+	v := newItemVars()
+	s.items[item] = v
+	s.order = append(s.order, item)
+	if s.constraints.references[item] {
+		rect := item.FrameRect()
+		type rooted interface {
+			PointToRoot(pt geom.Point) geom.Point
+		}
+		if ri, ok := item.(rooted); ok {
+			if rt, ok2 := s.constraints.target.(rooted); ok2 {
+				pt := ri.PointToRoot(geom.Point{})
+				pt.Subtract(rt.PointToRoot(geom.Point{}))
+				rect.Point = pt
+			}
+		}
+		s.require(v.x, Equal, rect.X)
+		s.require(v.y, Equal, rect.Y)
+		s.require(v.w, Equal, rect.Width)
+		s.require(v.h, Equal, rect.Height)
+		return
+	}
+	min, pref, max := item.Sizes(geom.Size{})
+	if useMin {
+		pref = min
+	}
+	s.require(v.w, GreaterThanOrEqual, 0)
+	s.require(v.h, GreaterThanOrEqual, 0)
+	s.prefer(v.w, GreaterThanOrEqual, min.Width, Strong)
+	s.prefer(v.h, GreaterThanOrEqual, min.Height, Strong)
+	s.prefer(v.w, LessThanOrEqual, max.Width, Strong)
+	s.prefer(v.h, LessThanOrEqual, max.Height, Strong)
+	s.prefer(v.w, Equal, pref.Width, Medium)
+	s.prefer(v.h, Equal, pref.Height, Medium)
+	t := s.target
+	s.add(newExpression(0).addTerm(v.x, 1).addTerm(t.x, -1), GreaterThanOrEqual, Strong)
+	s.add(newExpression(0).addTerm(v.y, 1).addTerm(t.y, -1), GreaterThanOrEqual, Strong)
+	s.add(newExpression(0).addTerm(v.x, 1).addTerm(v.w, 1).addTerm(t.x, -1).addTerm(t.w, -1), LessThanOrEqual, Strong)
+	s.add(newExpression(0).addTerm(v.y, 1).addTerm(v.h, 1).addTerm(t.y, -1).addTerm(t.h, -1), LessThanOrEqual, Strong)
+}
+
+func (s *solution) require(v *variable, relation Relation, value float64) {
+	s.prefer(v, relation, value, Required)
+}
+
+func (s *solution) prefer(v *variable, relation Relation, value float64, priority Priority) {
+	s.add(newExpression(-value).addTerm(v, 1), relation, priority)
+}
+
+func (s *solution) add(expr *expression, relation Relation, priority Priority) {
+	// Failures here can only come from conflicts between the implicit
+	// constraints, which are all satisfiable on their own.
+	_ = s.solver.add(&equation{expr: expr, relation: relation, strength: float64(priority)}) //nolint:errcheck
+}
+
+func (s *solution) attrExpr(item layout.Layoutable, attr Attribute) *expression {
+	v := s.target
+	if item != nil {
+		v = s.items[item]
+	}
+	expr := newExpression(0)
+	switch attr {
+	case Right:
+		expr.addTerm(v.x, 1).addTerm(v.w, 1)
+	case Top:
+		expr.addTerm(v.y, 1)
+	case Bottom:
+		expr.addTerm(v.y, 1).addTerm(v.h, 1)
+	case Width:
+		expr.addTerm(v.w, 1)
+	case Height:
+		expr.addTerm(v.h, 1)
+	case CenterX:
+		expr.addTerm(v.x, 1).addTerm(v.w, 0.5)
+	case CenterY:
+		expr.addTerm(v.y, 1).addTerm(v.h, 0.5)
+	default: // Left
+		expr.addTerm(v.x, 1)
+	}
+	return expr
+}
+
+func (c *Constraints) nameOf(item layout.Layoutable) string {
+	if item == nil {
+		return "target"
+	}
+	if name, ok := c.names[item]; ok {
+		return name
+	}
+	if c.names == nil {
+		c.names = make(map[layout.Layoutable]string)
+	}
+	name := fmt.Sprintf("item%d", len(c.names)+1)
+	c.names[item] = name
+	return name
+}
+
+func (c *Constraints) describe(cn *Constraint) string {
+	var buffer strings.Builder
+	buffer.WriteString(c.nameOf(cn.item))
+	buffer.WriteByte('.')
+	buffer.WriteString(cn.attr.String())
+	buffer.WriteByte(' ')
+	buffer.WriteString(cn.relation.String())
+	buffer.WriteByte(' ')
+	if cn.hasOther {
+		buffer.WriteString(c.nameOf(cn.other))
+		buffer.WriteByte('.')
+		buffer.WriteString(cn.otherAttr.String())
+		if cn.multiplier != 1 {
+			fmt.Fprintf(&buffer, " * %v", cn.multiplier)
+		}
+		if cn.constant != 0 {
+			fmt.Fprintf(&buffer, " + %v", cn.constant)
+		}
+	} else {
+		fmt.Fprintf(&buffer, "%v", cn.constant)
+	}
+	fmt.Fprintf(&buffer, " (priority %s)", cn.priority)
+	return buffer.String()
+}
+
+// String implements fmt.Stringer.
+func (a Attribute) String() string {
+	switch a {
+	case Left:
+		return "left"
+	case Right:
+		return "right"
+	case Top:
+		return "top"
+	case Bottom:
+		return "bottom"
+	case Width:
+		return "width"
+	case Height:
+		return "height"
+	case CenterX:
+		return "centerX"
+	case CenterY:
+		return "centerY"
+	default:
+		return fmt.Sprintf("Attribute(%d)", a)
+	}
+}
+
+// String implements fmt.Stringer.
+func (r Relation) String() string {
+	switch r {
+	case LessThanOrEqual:
+		return "<="
+	case GreaterThanOrEqual:
+		return ">="
+	default:
+		return "=="
+	}
+}
+
+// String implements fmt.Stringer.
+func (p Priority) String() string {
+	switch p {
+	case Required:
+		return "required"
+	case Strong:
+		return "strong"
+	case Medium:
+		return "medium"
+	case Weak:
+		return "weak"
+	default:
+		return fmt.Sprintf("%v", float64(p))
+	}
+}
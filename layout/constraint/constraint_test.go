@@ -0,0 +1,57 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package constraint_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ux/layout"
+	"github.com/richardwilkes/ux/layout/constraint"
+	"github.com/richardwilkes/ux/layout/layouttest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConstraints(t *testing.T) {
+	a := &layouttest.Item{Pref: geom.Size{Width: 50, Height: 20}}
+	b := &layouttest.Item{Pref: geom.Size{Width: 100, Height: 20}}
+	target := &layouttest.Item{Children: []layout.Layoutable{a, b}}
+	constraint.New().Add(
+		constraint.Make(a, constraint.Left).EqualTo(nil, constraint.Left).Plus(10),
+		constraint.Make(a, constraint.Top).EqualTo(nil, constraint.Top).Plus(5),
+		constraint.Make(b, constraint.Left).EqualTo(a, constraint.Right).Plus(8),
+		constraint.Make(b, constraint.Top).EqualTo(a, constraint.Top),
+		constraint.Make(b, constraint.Width).EqualTo(a, constraint.Width).Times(2),
+		constraint.Make(nil, constraint.Right).EqualTo(b, constraint.Right).Plus(10),
+		constraint.Make(nil, constraint.Bottom).EqualTo(b, constraint.Bottom).Plus(5),
+	).Apply(target)
+	_, pref, _ := target.Sizes(geom.Size{})
+	assert.Equal(t, geom.Size{Width: 10 + 50 + 8 + 100 + 10, Height: 5 + 20 + 5}, pref)
+	target.SetFrameRect(geom.Rect{Size: pref})
+	target.Layout.Layout()
+	assert.Equal(t, geom.Rect{Point: geom.Point{X: 10, Y: 5}, Size: geom.Size{Width: 50, Height: 20}}, a.Frame)
+	assert.Equal(t, geom.Rect{Point: geom.Point{X: 68, Y: 5}, Size: geom.Size{Width: 100, Height: 20}}, b.Frame)
+	assert.NoError(t, target.Layout.(*constraint.Constraints).Err())
+}
+
+func TestUnsatisfiableConstraints(t *testing.T) {
+	a := &layouttest.Item{Pref: geom.Size{Width: 50, Height: 20}}
+	target := &layouttest.Item{Children: []layout.Layoutable{a}}
+	constraint.New().Name(a, "field").Add(
+		constraint.Make(a, constraint.Width).EqualToConstant(40),
+		constraint.Make(a, constraint.Width).GreaterThanOrEqualToConstant(60),
+	).Apply(target)
+	target.SetFrameRect(geom.Rect{Size: geom.Size{Width: 100, Height: 100}})
+	target.Layout.Layout()
+	err := target.Layout.(*constraint.Constraints).Err()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "field.width >= 60 (priority required)")
+	assert.Equal(t, 40.0, a.Frame.Width)
+}
@@ -0,0 +1,385 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package constraint
+
+import (
+	"math"
+
+	"github.com/richardwilkes/toolbox/errs"
+)
+
+// This file contains an implementation of the Cassowary incremental
+// constraint solving algorithm, modeled after the Kiwi implementation.
+
+type symbolKind uint8
+
+const (
+	invalidSymbol symbolKind = iota
+	externalSymbol
+	slackSymbol
+	errorSymbol
+	dummySymbol
+)
+
+type symbol struct {
+	id   int
+	kind symbolKind
+}
+
+func (s symbol) valid() bool {
+	return s.kind != invalidSymbol
+}
+
+// pivotable returns true for symbols that may be chosen as a row's subject
+// when not external.
+func (s symbol) pivotable() bool {
+	return s.kind == slackSymbol || s.kind == errorSymbol
+}
+
+type variable struct {
+	value float64
+}
+
+type expression struct {
+	terms    map[*variable]float64
+	constant float64
+}
+
+func newExpression(constant float64) *expression {
+	return &expression{terms: make(map[*variable]float64), constant: constant}
+}
+
+func (e *expression) addTerm(v *variable, coefficient float64) *expression {
+	e.terms[v] += coefficient
+	return e
+}
+
+func (e *expression) addExpression(other *expression, multiplier float64) *expression {
+	for v, c := range other.terms {
+		e.terms[v] += c * multiplier
+	}
+	e.constant += other.constant * multiplier
+	return e
+}
+
+// equation is expression (relation) 0.
+type equation struct {
+	expr     *expression
+	relation Relation
+	strength float64
+}
+
+type row struct {
+	cells    map[symbol]float64
+	constant float64
+}
+
+func newRow(constant float64) *row {
+	return &row{cells: make(map[symbol]float64), constant: constant}
+}
+
+func (r *row) clone() *row {
+	other := newRow(r.constant)
+	for s, c := range r.cells {
+		other.cells[s] = c
+	}
+	return other
+}
+
+func (r *row) insertSymbol(s symbol, coefficient float64) {
+	if c := r.cells[s] + coefficient; nearZero(c) {
+		delete(r.cells, s)
+	} else {
+		r.cells[s] = c
+	}
+}
+
+func (r *row) insertRow(other *row, coefficient float64) {
+	r.constant += other.constant * coefficient
+	for s, c := range other.cells {
+		r.insertSymbol(s, c*coefficient)
+	}
+}
+
+func (r *row) reverseSign() {
+	r.constant = -r.constant
+	for s, c := range r.cells {
+		r.cells[s] = -c
+	}
+}
+
+func (r *row) solveFor(s symbol) {
+	coefficient := -1 / r.cells[s]
+	delete(r.cells, s)
+	r.constant *= coefficient
+	for one, c := range r.cells {
+		r.cells[one] = c * coefficient
+	}
+}
+
+func (r *row) solveForPair(lhs, rhs symbol) {
+	r.insertSymbol(lhs, -1)
+	r.solveFor(rhs)
+}
+
+func (r *row) substitute(s symbol, other *row) {
+	if c, ok := r.cells[s]; ok {
+		delete(r.cells, s)
+		r.insertRow(other, c)
+	}
+}
+
+type tag struct {
+	marker symbol
+	other  symbol
+}
+
+type solver struct {
+	rows       map[symbol]*row
+	vars       map[*variable]symbol
+	objective  *row
+	artificial *row
+	nextID     int
+}
+
+func newSolver() *solver {
+	return &solver{
+		rows:      make(map[symbol]*row),
+		vars:      make(map[*variable]symbol),
+		objective: newRow(0),
+	}
+}
+
+func (s *solver) newSymbol(kind symbolKind) symbol {
+	s.nextID++
+	return symbol{id: s.nextID, kind: kind}
+}
+
+func (s *solver) add(eq *equation) error {
+	var t tag
+	r := s.createRow(eq, &t)
+	subject := s.chooseSubject(r, &t)
+	if !subject.valid() && allDummies(r) {
+		if !nearZero(r.constant) {
+			return errs.New("unsatisfiable")
+		}
+		subject = t.marker
+	}
+	if !subject.valid() {
+		ok, err := s.addWithArtificialVariable(r)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errs.New("unsatisfiable")
+		}
+	} else {
+		r.solveFor(subject)
+		s.substitute(subject, r)
+		s.rows[subject] = r
+	}
+	return s.optimize(s.objective)
+}
+
+func (s *solver) createRow(eq *equation, t *tag) *row {
+	r := newRow(eq.expr.constant)
+	for v, c := range eq.expr.terms {
+		if nearZero(c) {
+			continue
+		}
+		sym := s.symbolFor(v)
+		if other, ok := s.rows[sym]; ok {
+			r.insertRow(other, c)
+		} else {
+			r.insertSymbol(sym, c)
+		}
+	}
+	switch eq.relation {
+	case LessThanOrEqual, GreaterThanOrEqual:
+		coefficient := 1.0
+		if eq.relation == GreaterThanOrEqual {
+			coefficient = -1
+		}
+		slack := s.newSymbol(slackSymbol)
+		t.marker = slack
+		r.insertSymbol(slack, coefficient)
+		if eq.strength < float64(Required) {
+			e := s.newSymbol(errorSymbol)
+			t.other = e
+			r.insertSymbol(e, -coefficient)
+			s.objective.insertSymbol(e, eq.strength)
+		}
+	default:
+		if eq.strength < float64(Required) {
+			plus := s.newSymbol(errorSymbol)
+			minus := s.newSymbol(errorSymbol)
+			t.marker = plus
+			t.other = minus
+			r.insertSymbol(plus, -1)
+			r.insertSymbol(minus, 1)
+			s.objective.insertSymbol(plus, eq.strength)
+			s.objective.insertSymbol(minus, eq.strength)
+		} else {
+			dummy := s.newSymbol(dummySymbol)
+			t.marker = dummy
+			r.insertSymbol(dummy, 1)
+		}
+	}
+	if r.constant < 0 {
+		r.reverseSign()
+	}
+	return r
+}
+
+func (s *solver) symbolFor(v *variable) symbol {
+	sym, ok := s.vars[v]
+	if !ok {
+		sym = s.newSymbol(externalSymbol)
+		s.vars[v] = sym
+	}
+	return sym
+}
+
+func (s *solver) chooseSubject(r *row, t *tag) symbol {
+	var subject symbol
+	for sym := range r.cells {
+		if sym.kind == externalSymbol && (!subject.valid() || sym.id < subject.id) {
+			subject = sym
+		}
+	}
+	if subject.valid() {
+		return subject
+	}
+	if t.marker.pivotable() && r.cells[t.marker] < 0 {
+		return t.marker
+	}
+	if t.other.pivotable() && r.cells[t.other] < 0 {
+		return t.other
+	}
+	return symbol{}
+}
+
+func allDummies(r *row) bool {
+	for sym := range r.cells {
+		if sym.kind != dummySymbol {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *solver) addWithArtificialVariable(r *row) (bool, error) {
+	art := s.newSymbol(slackSymbol)
+	s.rows[art] = r.clone()
+	s.artificial = r.clone()
+	if err := s.optimize(s.artificial); err != nil {
+		return false, err
+	}
+	success := nearZero(s.artificial.constant)
+	s.artificial = nil
+	if artRow, ok := s.rows[art]; ok {
+		delete(s.rows, art)
+		if len(artRow.cells) == 0 {
+			return success, nil
+		}
+		entering := anyPivotableSymbol(artRow)
+		if !entering.valid() {
+			return false, nil
+		}
+		artRow.solveForPair(art, entering)
+		s.substitute(entering, artRow)
+		s.rows[entering] = artRow
+	}
+	for _, one := range s.rows {
+		delete(one.cells, art)
+	}
+	delete(s.objective.cells, art)
+	return success, nil
+}
+
+func anyPivotableSymbol(r *row) symbol {
+	var found symbol
+	for sym := range r.cells {
+		if sym.pivotable() && (!found.valid() || sym.id < found.id) {
+			found = sym
+		}
+	}
+	return found
+}
+
+func (s *solver) substitute(sym symbol, r *row) {
+	for _, other := range s.rows {
+		other.substitute(sym, r)
+	}
+	s.objective.substitute(sym, r)
+	if s.artificial != nil {
+		s.artificial.substitute(sym, r)
+	}
+}
+
+func (s *solver) optimize(objective *row) error {
+	for {
+		entering := enteringSymbol(objective)
+		if !entering.valid() {
+			return nil
+		}
+		leaving := s.leavingSymbol(entering)
+		if !leaving.valid() {
+			return errs.New("the objective is unbounded")
+		}
+		r := s.rows[leaving]
+		delete(s.rows, leaving)
+		r.solveForPair(leaving, entering)
+		s.substitute(entering, r)
+		s.rows[entering] = r
+	}
+}
+
+// enteringSymbol uses Bland's rule, picking the lowest numbered candidate, to
+// avoid cycling.
+func enteringSymbol(objective *row) symbol {
+	var found symbol
+	for sym, c := range objective.cells {
+		if sym.kind != dummySymbol && c < 0 && (!found.valid() || sym.id < found.id) {
+			found = sym
+		}
+	}
+	return found
+}
+
+func (s *solver) leavingSymbol(entering symbol) symbol {
+	var found symbol
+	ratio := math.MaxFloat64
+	for sym, r := range s.rows {
+		if sym.kind != externalSymbol {
+			if c := r.cells[entering]; c < 0 {
+				if value := -r.constant / c; value < ratio || (value == ratio && sym.id < found.id) {
+					ratio = value
+					found = sym
+				}
+			}
+		}
+	}
+	return found
+}
+
+func (s *solver) updateVariables() {
+	for v, sym := range s.vars {
+		if r, ok := s.rows[sym]; ok {
+			v.value = r.constant
+		} else {
+			v.value = 0
+		}
+	}
+}
+
+func nearZero(value float64) bool {
+	return math.Abs(value) < 1.0e-8
+}
@@ -0,0 +1,140 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package layout
+
+import (
+	"strings"
+
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ux/layout/align"
+	"github.com/richardwilkes/ux/layout/side"
+)
+
+// Direction constants.
+const (
+	// InheritDirection means the direction should be obtained from the
+	// enclosing object.
+	InheritDirection Direction = iota
+	LeftToRight
+	RightToLeft
+)
+
+// Direction specifies the horizontal direction content flows in.
+type Direction uint8
+
+// DefaultDirection is the direction used when nothing in an object's
+// hierarchy specifies one. It is initialized based on i18n.Language.
+var DefaultDirection = DirectionForLanguage(i18n.Language)
+
+// Directional defines the method an object that participates in layout may
+// implement to specify the direction its content flows in. Layouts mirror
+// the horizontal placement of the children of targets that report
+// RightToLeft.
+type Directional interface {
+	LayoutDirection() Direction
+}
+
+// DirectionForLanguage returns RightToLeft for languages that are written
+// right-to-left and LeftToRight for all others. The language is expected to
+// be in the same form as i18n.Language, e.g. "ar_EG.UTF-8" or "he".
+func DirectionForLanguage(language string) Direction {
+	language = strings.ToLower(language)
+	if i := strings.IndexAny(language, "_-.@"); i != -1 {
+		language = language[:i]
+	}
+	switch language {
+	case "ar", "arc", "ckb", "dv", "fa", "ha", "he", "iw", "khw", "ks", "ps", "sd", "ug", "ur", "yi":
+		return RightToLeft
+	default:
+		return LeftToRight
+	}
+}
+
+// DirectionOf returns the direction of the target. If the target does not
+// implement Directional or reports InheritDirection, DefaultDirection is
+// returned.
+func DirectionOf(target interface{}) Direction {
+	if d, ok := target.(Directional); ok {
+		if dir := d.LayoutDirection(); dir != InheritDirection {
+			return dir
+		}
+	}
+	if DefaultDirection == InheritDirection {
+		return LeftToRight
+	}
+	return DefaultDirection
+}
+
+// RightToLeft returns true if the direction is RightToLeft.
+func (d Direction) RightToLeft() bool {
+	return d == RightToLeft
+}
+
+// Alignment returns the horizontal alignment to use for this direction,
+// swapping Start and End when it is RightToLeft.
+func (d Direction) Alignment(hAlign align.Alignment) align.Alignment {
+	if d == RightToLeft {
+		switch hAlign {
+		case align.Start:
+			return align.End
+		case align.End:
+			return align.Start
+		}
+	}
+	return hAlign
+}
+
+// Side returns the side to use for this direction, swapping Left and Right
+// when it is RightToLeft.
+func (d Direction) Side(s side.Side) side.Side {
+	if d == RightToLeft {
+		switch s {
+		case side.Left:
+			return side.Right
+		case side.Right:
+			return side.Left
+		}
+	}
+	return s
+}
+
+// Mirror flips rects horizontally within the content area of a target whose
+// direction is RightToLeft.
+type Mirror struct {
+	enabled bool
+	left    float64
+	right   float64
+}
+
+// MirrorFor returns a Mirror for the target. If the target's direction is
+// not RightToLeft, the Mirror leaves rects unchanged.
+func MirrorFor(target Layoutable) Mirror {
+	if DirectionOf(target) != RightToLeft {
+		return Mirror{}
+	}
+	var insets geom.Insets
+	if b := target.Border(); b != nil {
+		insets = b.Insets()
+	}
+	return Mirror{
+		enabled: true,
+		left:    insets.Left,
+		right:   target.FrameRect().Width - insets.Right,
+	}
+}
+
+// Rect returns the rect, flipped horizontally if mirroring is in effect.
+func (m Mirror) Rect(rect geom.Rect) geom.Rect {
+	if m.enabled {
+		rect.X = m.left + m.right - (rect.X + rect.Width)
+	}
+	return rect
+}
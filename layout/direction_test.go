@@ -0,0 +1,36 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package layout_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/ux/layout"
+	"github.com/richardwilkes/ux/layout/align"
+	"github.com/richardwilkes/ux/layout/side"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDirectionForLanguage(t *testing.T) {
+	assert.Equal(t, layout.RightToLeft, layout.DirectionForLanguage("ar_EG.UTF-8"))
+	assert.Equal(t, layout.RightToLeft, layout.DirectionForLanguage("he"))
+	assert.Equal(t, layout.RightToLeft, layout.DirectionForLanguage("FA-IR"))
+	assert.Equal(t, layout.LeftToRight, layout.DirectionForLanguage("en_US.UTF-8"))
+	assert.Equal(t, layout.LeftToRight, layout.DirectionForLanguage(""))
+}
+
+func TestDirectionMirroring(t *testing.T) {
+	assert.Equal(t, align.End, layout.RightToLeft.Alignment(align.Start))
+	assert.Equal(t, align.Middle, layout.RightToLeft.Alignment(align.Middle))
+	assert.Equal(t, align.Start, layout.LeftToRight.Alignment(align.Start))
+	assert.Equal(t, side.Right, layout.RightToLeft.Side(side.Left))
+	assert.Equal(t, side.Top, layout.RightToLeft.Side(side.Top))
+	assert.Equal(t, side.Left, layout.LeftToRight.Side(side.Left))
+}
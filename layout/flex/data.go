@@ -10,6 +10,8 @@
 package flex
 
 import (
+	"fmt"
+
 	"github.com/richardwilkes/toolbox/xmath/geom"
 	"github.com/richardwilkes/ux/layout"
 	"github.com/richardwilkes/ux/layout/align"
@@ -101,6 +103,11 @@ func (f *Data) Apply(target layout.Layoutable) {
 	target.SetLayoutData(&flexData)
 }
 
+// String implements fmt.Stringer.
+func (f *Data) String() string {
+	return fmt.Sprintf("span %dx%d, align %v/%v, grab %v/%v, hint %v, min %v", f.hSpan, f.vSpan, f.hAlign, f.vAlign, f.hGrab, f.vGrab, f.sizeHint, f.minSize)
+}
+
 func (f *Data) normalizeAndResetCache() {
 	f.cacheSize.Width = 0
 	f.cacheSize.Height = 0
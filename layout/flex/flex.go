@@ -10,7 +10,9 @@
 package flex
 
 import (
+	"fmt"
 	"math"
+	"strings"
 
 	"github.com/richardwilkes/toolbox/xmath"
 	"github.com/richardwilkes/toolbox/xmath/geom"
@@ -19,7 +21,8 @@ import (
 )
 
 // Flex lays out the children of its Layoutable based on the Data assigned to
-// each child.
+// each child. If the Layoutable's direction is RightToLeft, columns are
+// ordered from right to left and horizontal alignments are mirrored.
 type Flex struct {
 	target       layout.Layoutable
 	rows         int
@@ -29,6 +32,9 @@ type Flex struct {
 	hAlign       align.Alignment
 	vAlign       align.Alignment
 	equalColumns bool
+	lastGrid     [][]layout.Layoutable
+	lastWidths   []float64
+	lastHeights  []float64
 }
 
 // New creates a new Flex layout and sets it on the Layoutable.
@@ -114,6 +120,47 @@ func (f *Flex) Layout() {
 	f.layout(geom.Point{X: insets.Left, Y: insets.Top}, hint, true, false)
 }
 
+// Dump returns a description of the grid computed by the most recent call to
+// Layout(), suitable for debugging. Each cell shows the index of the child
+// occupying it within the target's children, or a dash if it is empty.
+func (f *Flex) Dump() string {
+	if f.lastGrid == nil {
+		return "no layout has been performed"
+	}
+	indexes := make(map[layout.Layoutable]int)
+	if f.target != nil {
+		for i, child := range f.target.ChildrenForLayout() {
+			indexes[child] = i
+		}
+	}
+	var buffer strings.Builder
+	fmt.Fprintf(&buffer, "%d columns x %d rows\n", len(f.lastWidths), len(f.lastHeights))
+	buffer.WriteString("column widths:")
+	for _, w := range f.lastWidths {
+		fmt.Fprintf(&buffer, " %v", w)
+	}
+	buffer.WriteString("\nrow heights:")
+	for _, h := range f.lastHeights {
+		fmt.Fprintf(&buffer, " %v", h)
+	}
+	for _, row := range f.lastGrid {
+		buffer.WriteString("\n")
+		for j, cell := range row {
+			if j != 0 {
+				buffer.WriteString(" ")
+			}
+			if cell == nil {
+				buffer.WriteString("-")
+			} else if i, ok := indexes[cell]; ok {
+				fmt.Fprintf(&buffer, "%d", i)
+			} else {
+				buffer.WriteString("?")
+			}
+		}
+	}
+	return buffer.String()
+}
+
 func (f *Flex) layout(location geom.Point, hint geom.Size, move, useMinimumSize bool) geom.Size {
 	var totalSize geom.Size
 	if f.columns > 0 {
@@ -153,6 +200,9 @@ func (f *Flex) layout(location geom.Point, hint geom.Size, move, useMinimumSize
 					}
 				}
 				f.positionChildren(location, grid, widths, heights)
+				f.lastGrid = grid[:f.rows]
+				f.lastWidths = widths
+				f.lastHeights = heights
 			}
 		}
 	}
@@ -612,6 +662,7 @@ func (f *Flex) adjustRowHeights(height float64, grid [][]layout.Layoutable) []fl
 }
 
 func (f *Flex) positionChildren(location geom.Point, grid [][]layout.Layoutable, widths, heights []float64) {
+	mirror := layout.MirrorFor(f.target)
 	gridY := location.Y
 	for i := 0; i < f.rows; i++ {
 		gridX := location.X
@@ -653,7 +704,7 @@ func (f *Flex) positionChildren(location geom.Point, grid [][]layout.Layoutable,
 				}
 				child := grid[i][j]
 				if child != nil {
-					child.SetFrameRect(geom.Rect{Point: geom.Point{X: childX, Y: childY}, Size: geom.Size{Width: childWidth, Height: childHeight}})
+					child.SetFrameRect(mirror.Rect(geom.Rect{Point: geom.Point{X: childX, Y: childY}, Size: geom.Size{Width: childWidth, Height: childHeight}}))
 				}
 			}
 			gridX += widths[j] + f.hSpacing
@@ -0,0 +1,84 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package flexbox
+
+import (
+	"github.com/richardwilkes/ux/layout"
+	"github.com/richardwilkes/ux/layout/align"
+)
+
+// Data is used to control how an object is laid out by the Flexbox layout.
+type Data struct {
+	grow         float64
+	shrink       float64
+	basis        float64
+	alignSelf    align.Alignment
+	hasBasis     bool
+	hasAlignSelf bool
+}
+
+// NewData creates new flexbox layout data.
+func NewData() *Data {
+	return &Data{shrink: 1}
+}
+
+// Grow sets the proportion of the free space along the main axis that the
+// target should receive when there is more space than needed. Defaults to 0.
+func (d *Data) Grow(grow float64) *Data {
+	d.grow = grow
+	return d
+}
+
+// Shrink sets how much the target should shrink relative to the others along
+// the main axis when there is less space than needed. The amount is weighted
+// by the target's basis. Defaults to 1.
+func (d *Data) Shrink(shrink float64) *Data {
+	d.shrink = shrink
+	return d
+}
+
+// Basis sets the initial main axis size of the target, before any growing or
+// shrinking. A basis of 0 is valid and makes the target's size depend solely
+// on its share of the free space. Defaults to using the target's preferred
+// size.
+func (d *Data) Basis(basis float64) *Data {
+	d.basis = basis
+	d.hasBasis = true
+	return d
+}
+
+// AlignSelf sets the alignment of the target along the cross axis,
+// overriding the layout's AlignItems setting. Defaults to using the layout's
+// setting.
+func (d *Data) AlignSelf(alignment align.Alignment) *Data {
+	d.alignSelf = alignment
+	d.hasAlignSelf = true
+	return d
+}
+
+// Apply the layout data to the target. A copy is made of this data and that
+// is applied to the target, so this data may be applied to other targets.
+func (d *Data) Apply(target layout.Layoutable) {
+	data := *d
+	data.normalize()
+	target.SetLayoutData(&data)
+}
+
+func (d *Data) normalize() {
+	if d.grow < 0 {
+		d.grow = 0
+	}
+	if d.shrink < 0 {
+		d.shrink = 0
+	}
+	if d.basis < 0 {
+		d.basis = 0
+	}
+}
@@ -0,0 +1,448 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package flexbox
+
+import (
+	"math"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ux/layout"
+	"github.com/richardwilkes/ux/layout/align"
+)
+
+// Direction constants.
+const (
+	Row Direction = iota
+	RowReverse
+	Column
+	ColumnReverse
+)
+
+// Direction specifies the main axis of a Flexbox and the order in which
+// children are placed along it.
+type Direction uint8
+
+// Justify constants.
+const (
+	JustifyStart Justify = iota
+	JustifyEnd
+	JustifyCenter
+	JustifySpaceBetween
+	JustifySpaceAround
+	JustifySpaceEvenly
+)
+
+// Justify specifies how free space along the main axis is distributed.
+type Justify uint8
+
+// Flexbox lays out the children of its Layoutable in lines along a main
+// axis, growing and shrinking them according to the Data assigned to each
+// child.
+type Flexbox struct {
+	target     layout.Layoutable
+	direction  Direction
+	justify    Justify
+	alignItems align.Alignment
+	hSpacing   float64
+	vSpacing   float64
+	wrap       bool
+}
+
+type item struct {
+	target    layout.Layoutable
+	data      *Data
+	min       geom.Size
+	max       geom.Size
+	basis     float64
+	main      float64
+	cross     float64
+	frozen    bool
+	violation float64
+}
+
+// New creates a new Flexbox layout. Children without Data are treated as if
+// they had the result of NewData() applied to them.
+func New() *Flexbox {
+	return &Flexbox{
+		alignItems: align.Fill,
+		hSpacing:   layout.DefaultHSpacing,
+		vSpacing:   layout.DefaultVSpacing,
+	}
+}
+
+// Direction sets the main axis direction. Defaults to Row.
+func (f *Flexbox) Direction(direction Direction) *Flexbox {
+	f.direction = direction
+	return f
+}
+
+// Wrap sets whether children may be placed on additional lines when they
+// don't fit along the main axis. Defaults to false.
+func (f *Flexbox) Wrap(wrap bool) *Flexbox {
+	f.wrap = wrap
+	return f
+}
+
+// Justify sets how free space along the main axis is distributed. Defaults
+// to JustifyStart.
+func (f *Flexbox) Justify(justify Justify) *Flexbox {
+	f.justify = justify
+	return f
+}
+
+// AlignItems sets the alignment of children along the cross axis within
+// their line. May be overridden by Data.AlignSelf(). Defaults to Fill.
+func (f *Flexbox) AlignItems(alignment align.Alignment) *Flexbox {
+	f.alignItems = alignment
+	return f
+}
+
+// HSpacing sets the horizontal gap between children. Defaults to
+// DefaultHSpacing.
+func (f *Flexbox) HSpacing(hSpacing float64) *Flexbox {
+	f.hSpacing = hSpacing
+	return f
+}
+
+// VSpacing sets the vertical gap between children. Defaults to
+// DefaultVSpacing.
+func (f *Flexbox) VSpacing(vSpacing float64) *Flexbox {
+	f.vSpacing = vSpacing
+	return f
+}
+
+// Apply the layout to the target. A copy is made of this layout and that is
+// applied to the target, so this layout may be applied to other targets.
+func (f *Flexbox) Apply(target layout.Layoutable) {
+	flexbox := *f
+	flexbox.target = target
+	target.SetLayout(&flexbox)
+}
+
+func (f *Flexbox) horizontal() bool {
+	return f.direction == Row || f.direction == RowReverse
+}
+
+func (f *Flexbox) reversed() bool {
+	return f.direction == RowReverse || f.direction == ColumnReverse
+}
+
+func (f *Flexbox) mainOf(size geom.Size) float64 {
+	if f.horizontal() {
+		return size.Width
+	}
+	return size.Height
+}
+
+func (f *Flexbox) crossOf(size geom.Size) float64 {
+	if f.horizontal() {
+		return size.Height
+	}
+	return size.Width
+}
+
+func (f *Flexbox) sizeOf(main, cross float64) geom.Size {
+	if f.horizontal() {
+		return geom.Size{Width: main, Height: cross}
+	}
+	return geom.Size{Width: cross, Height: main}
+}
+
+func (f *Flexbox) mainGap() float64 {
+	if f.horizontal() {
+		return math.Max(f.hSpacing, 0)
+	}
+	return math.Max(f.vSpacing, 0)
+}
+
+func (f *Flexbox) crossGap() float64 {
+	if f.horizontal() {
+		return math.Max(f.vSpacing, 0)
+	}
+	return math.Max(f.hSpacing, 0)
+}
+
+func (f *Flexbox) insets() geom.Insets {
+	if border := f.target.Border(); border != nil {
+		return border.Insets()
+	}
+	return geom.Insets{}
+}
+
+func (f *Flexbox) items(useMinimumSize bool) []*item {
+	children := f.target.ChildrenForLayout()
+	items := make([]*item, 0, len(children))
+	for _, child := range children {
+		data, ok := child.LayoutData().(*Data)
+		if !ok || data == nil {
+			data = NewData()
+		}
+		min, pref, max := child.Sizes(geom.Size{})
+		one := &item{target: child, data: data, min: min, max: max}
+		switch {
+		case data.hasBasis:
+			one.basis = data.basis
+		case useMinimumSize:
+			one.basis = f.mainOf(min)
+		default:
+			one.basis = f.mainOf(pref)
+		}
+		one.basis = one.clampMain(f, one.basis)
+		items = append(items, one)
+	}
+	return items
+}
+
+func (it *item) clampMain(f *Flexbox, size float64) float64 {
+	if max := f.mainOf(it.max); size > max {
+		size = max
+	}
+	if min := f.mainOf(it.min); size < min {
+		size = min
+	}
+	return size
+}
+
+func (f *Flexbox) lines(items []*item, availMain float64) [][]*item {
+	if len(items) == 0 {
+		return nil
+	}
+	if !f.wrap {
+		return [][]*item{items}
+	}
+	gap := f.mainGap()
+	var lines [][]*item
+	var line []*item
+	used := 0.0
+	for _, one := range items {
+		if len(line) != 0 && used+gap+one.basis > availMain {
+			lines = append(lines, line)
+			line = nil
+			used = 0
+		}
+		if len(line) != 0 {
+			used += gap
+		}
+		used += one.basis
+		line = append(line, one)
+	}
+	return append(lines, line)
+}
+
+// resolve determines the main axis size of each item in the line by
+// distributing the free space according to their grow and shrink factors,
+// freezing any that hit their limits and repeating until stable.
+func (f *Flexbox) resolve(line []*item, availMain float64) {
+	gaps := f.mainGap() * float64(len(line)-1)
+	for _, one := range line {
+		one.frozen = false
+	}
+	for {
+		used := gaps
+		var growSum, shrinkSum float64
+		for _, one := range line {
+			if !one.frozen {
+				one.main = one.basis
+			}
+			used += one.main
+			if !one.frozen {
+				growSum += one.data.grow
+				shrinkSum += one.data.shrink * one.basis
+			}
+		}
+		free := availMain - used
+		if math.Abs(free) < 0.5 || (free > 0 && growSum == 0) || (free < 0 && shrinkSum == 0) {
+			return
+		}
+		totalViolation := 0.0
+		for _, one := range line {
+			if one.frozen {
+				continue
+			}
+			target := one.basis
+			if free > 0 {
+				target += free * one.data.grow / growSum
+			} else {
+				target += free * one.data.shrink * one.basis / shrinkSum
+			}
+			clamped := one.clampMain(f, target)
+			one.violation = clamped - target
+			totalViolation += one.violation
+			one.main = clamped
+		}
+		changed := false
+		for _, one := range line {
+			if one.frozen {
+				continue
+			}
+			if (totalViolation > 0 && one.violation > 0) || (totalViolation < 0 && one.violation < 0) || totalViolation == 0 {
+				one.frozen = true
+				changed = true
+			}
+		}
+		if !changed || totalViolation == 0 {
+			return
+		}
+	}
+}
+
+func (f *Flexbox) crossSizes(line []*item) float64 {
+	lineCross := 0.0
+	for _, one := range line {
+		_, pref, _ := one.target.Sizes(f.sizeOf(one.main, 0))
+		one.cross = math.Min(math.Max(f.crossOf(pref), f.crossOf(one.min)), f.crossOf(one.max))
+		lineCross = math.Max(lineCross, one.cross)
+	}
+	return lineCross
+}
+
+// Sizes implements Layout.
+func (f *Flexbox) Sizes(hint geom.Size) (min, pref, max geom.Size) {
+	insets := f.insets()
+	content := geom.Size{
+		Width:  math.Max(hint.Width-(insets.Left+insets.Right), 0),
+		Height: math.Max(hint.Height-(insets.Top+insets.Bottom), 0),
+	}
+	mainGap := f.mainGap()
+	crossGap := f.crossGap()
+	availMain := f.mainOf(content)
+	if availMain <= 0 {
+		availMain = math.MaxFloat64
+	}
+	items := f.items(false)
+	var prefMain, prefCross float64
+	for i, line := range f.lines(items, availMain) {
+		lineMain := mainGap * float64(len(line)-1)
+		for _, one := range line {
+			one.main = one.basis
+			lineMain += one.basis
+		}
+		prefMain = math.Max(prefMain, lineMain)
+		if i > 0 {
+			prefCross += crossGap
+		}
+		prefCross += f.crossSizes(line)
+	}
+	var minMain, minCross float64
+	for i, one := range f.items(true) {
+		oneMain := one.basis
+		if one.data.shrink > 0 {
+			oneMain = f.mainOf(one.min)
+		}
+		if f.wrap {
+			minMain = math.Max(minMain, oneMain)
+		} else {
+			if i > 0 {
+				minMain += mainGap
+			}
+			minMain += oneMain
+		}
+		minCross = math.Max(minCross, f.crossOf(one.min))
+	}
+	if f.wrap {
+		minCross = prefCross
+	}
+	pref = f.sizeOf(prefMain, prefCross)
+	min = f.sizeOf(math.Min(minMain, prefMain), math.Min(minCross, prefCross))
+	pref.AddInsets(insets)
+	min.AddInsets(insets)
+	return min, pref, layout.MaxSize(pref)
+}
+
+// Layout implements Layout.
+func (f *Flexbox) Layout() {
+	insets := f.insets()
+	size := f.target.FrameRect().Size
+	content := geom.Rect{
+		Point: geom.Point{X: insets.Left, Y: insets.Top},
+		Size: geom.Size{
+			Width:  math.Max(size.Width-(insets.Left+insets.Right), 0),
+			Height: math.Max(size.Height-(insets.Top+insets.Bottom), 0),
+		},
+	}
+	availMain := f.mainOf(content.Size)
+	availCross := f.crossOf(content.Size)
+	mainStart := content.X
+	crossPos := content.Y
+	if !f.horizontal() {
+		mainStart, crossPos = content.Y, content.X
+	}
+	mainGap := f.mainGap()
+	mirror := layout.MirrorFor(f.target)
+	lines := f.lines(f.items(false), availMain)
+	for _, line := range lines {
+		f.resolve(line, availMain)
+		lineCross := f.crossSizes(line)
+		if len(lines) == 1 {
+			lineCross = math.Max(lineCross, availCross)
+		}
+		free := availMain - mainGap*float64(len(line)-1)
+		for _, one := range line {
+			free -= one.main
+		}
+		free = math.Max(free, 0)
+		offset, extra := f.justifySpacing(free, len(line))
+		pos := mainStart + offset
+		for _, one := range line {
+			alignment := f.alignItems
+			if one.data.hasAlignSelf {
+				alignment = one.data.alignSelf
+			}
+			cross := one.cross
+			crossOffset := 0.0
+			switch alignment {
+			case align.Middle:
+				crossOffset = (lineCross - cross) / 2
+			case align.End:
+				crossOffset = lineCross - cross
+			case align.Fill:
+				cross = math.Max(math.Min(lineCross, f.crossOf(one.max)), f.crossOf(one.min))
+			default: // Start
+			}
+			mainPos := pos
+			if f.reversed() {
+				mainPos = mainStart + availMain - (pos - mainStart) - one.main
+			}
+			var rect geom.Rect
+			if f.horizontal() {
+				rect = geom.Rect{Point: geom.Point{X: mainPos, Y: crossPos + crossOffset}, Size: geom.Size{Width: one.main, Height: cross}}
+			} else {
+				rect = geom.Rect{Point: geom.Point{X: crossPos + crossOffset, Y: mainPos}, Size: geom.Size{Width: cross, Height: one.main}}
+			}
+			one.target.SetFrameRect(mirror.Rect(rect))
+			pos += one.main + mainGap + extra
+		}
+		crossPos += lineCross + f.crossGap()
+	}
+}
+
+// justifySpacing returns the offset of the first item and the extra space to
+// place between items for the free space along the main axis.
+func (f *Flexbox) justifySpacing(free float64, count int) (offset, extra float64) {
+	switch f.justify {
+	case JustifyEnd:
+		return free, 0
+	case JustifyCenter:
+		return free / 2, 0
+	case JustifySpaceBetween:
+		if count > 1 {
+			return 0, free / float64(count-1)
+		}
+		return 0, 0
+	case JustifySpaceAround:
+		extra = free / float64(count)
+		return extra / 2, extra
+	case JustifySpaceEvenly:
+		extra = free / float64(count+1)
+		return extra, extra
+	default: // JustifyStart
+		return 0, 0
+	}
+}
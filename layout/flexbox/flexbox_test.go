@@ -0,0 +1,246 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package flexbox_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ux/layout"
+	"github.com/richardwilkes/ux/layout/flexbox"
+	"github.com/richardwilkes/ux/layout/layouttest"
+	"github.com/stretchr/testify/assert"
+)
+
+type child struct {
+	pref geom.Size
+	max  geom.Size
+	data *flexbox.Data
+}
+
+func rect(x, y, width, height float64) geom.Rect {
+	return geom.Rect{Point: geom.Point{X: x, Y: y}, Size: geom.Size{Width: width, Height: height}}
+}
+
+func TestFlexbox(t *testing.T) {
+	small := geom.Size{Width: 50, Height: 20}
+	large := geom.Size{Width: 100, Height: 20}
+	for _, tc := range []struct {
+		name     string
+		flexbox  *flexbox.Flexbox
+		size     geom.Size
+		children []child
+		expected []geom.Rect
+	}{
+		{
+			name:     "no grow",
+			flexbox:  flexbox.New().HSpacing(0),
+			size:     geom.Size{Width: 300, Height: 20},
+			children: []child{{pref: small}, {pref: small}},
+			expected: []geom.Rect{rect(0, 0, 50, 20), rect(50, 0, 50, 20)},
+		},
+		{
+			name:    "grow",
+			flexbox: flexbox.New().HSpacing(0),
+			size:    geom.Size{Width: 300, Height: 20},
+			children: []child{
+				{pref: small, data: flexbox.NewData().Grow(1)},
+				{pref: small, data: flexbox.NewData().Grow(3)},
+			},
+			expected: []geom.Rect{rect(0, 0, 100, 20), rect(100, 0, 200, 20)},
+		},
+		{
+			name:    "grow with spacing",
+			flexbox: flexbox.New().HSpacing(10),
+			size:    geom.Size{Width: 310, Height: 20},
+			children: []child{
+				{pref: small, data: flexbox.NewData().Grow(1)},
+				{pref: small, data: flexbox.NewData().Grow(3)},
+			},
+			expected: []geom.Rect{rect(0, 0, 100, 20), rect(110, 0, 200, 20)},
+		},
+		{
+			name:    "grow limited by max",
+			flexbox: flexbox.New().HSpacing(0),
+			size:    geom.Size{Width: 300, Height: 20},
+			children: []child{
+				{pref: small, max: geom.Size{Width: 80, Height: 20}, data: flexbox.NewData().Grow(1)},
+				{pref: small, data: flexbox.NewData().Grow(1)},
+			},
+			expected: []geom.Rect{rect(0, 0, 80, 20), rect(80, 0, 220, 20)},
+		},
+		{
+			name:     "shrink",
+			flexbox:  flexbox.New().HSpacing(0),
+			size:     geom.Size{Width: 100, Height: 20},
+			children: []child{{pref: large}, {pref: large}},
+			expected: []geom.Rect{rect(0, 0, 50, 20), rect(50, 0, 50, 20)},
+		},
+		{
+			name:    "weighted shrink",
+			flexbox: flexbox.New().HSpacing(0),
+			size:    geom.Size{Width: 100, Height: 20},
+			children: []child{
+				{pref: large},
+				{pref: large, data: flexbox.NewData().Shrink(3)},
+			},
+			expected: []geom.Rect{rect(0, 0, 75, 20), rect(75, 0, 25, 20)},
+		},
+		{
+			name:    "no shrink",
+			flexbox: flexbox.New().HSpacing(0),
+			size:    geom.Size{Width: 150, Height: 20},
+			children: []child{
+				{pref: large, data: flexbox.NewData().Shrink(0)},
+				{pref: large},
+			},
+			expected: []geom.Rect{rect(0, 0, 100, 20), rect(100, 0, 50, 20)},
+		},
+		{
+			name:    "basis",
+			flexbox: flexbox.New().HSpacing(0),
+			size:    geom.Size{Width: 300, Height: 20},
+			children: []child{
+				{pref: small, data: flexbox.NewData().Basis(120)},
+				{pref: small},
+			},
+			expected: []geom.Rect{rect(0, 0, 120, 20), rect(120, 0, 50, 20)},
+		},
+		{
+			name:    "zero basis",
+			flexbox: flexbox.New().HSpacing(0),
+			size:    geom.Size{Width: 300, Height: 20},
+			children: []child{
+				{pref: small, data: flexbox.NewData().Basis(0).Grow(1)},
+				{pref: geom.Size{Width: 150, Height: 20}, data: flexbox.NewData().Basis(0).Grow(1)},
+			},
+			expected: []geom.Rect{rect(0, 0, 150, 20), rect(150, 0, 150, 20)},
+		},
+		{
+			name:     "justify end",
+			flexbox:  flexbox.New().HSpacing(0).Justify(flexbox.JustifyEnd),
+			size:     geom.Size{Width: 340, Height: 20},
+			children: []child{{pref: small}, {pref: small}},
+			expected: []geom.Rect{rect(240, 0, 50, 20), rect(290, 0, 50, 20)},
+		},
+		{
+			name:     "justify center",
+			flexbox:  flexbox.New().HSpacing(0).Justify(flexbox.JustifyCenter),
+			size:     geom.Size{Width: 340, Height: 20},
+			children: []child{{pref: small}, {pref: small}},
+			expected: []geom.Rect{rect(120, 0, 50, 20), rect(170, 0, 50, 20)},
+		},
+		{
+			name:     "justify space between",
+			flexbox:  flexbox.New().HSpacing(0).Justify(flexbox.JustifySpaceBetween),
+			size:     geom.Size{Width: 340, Height: 20},
+			children: []child{{pref: small}, {pref: small}},
+			expected: []geom.Rect{rect(0, 0, 50, 20), rect(290, 0, 50, 20)},
+		},
+		{
+			name:     "justify space around",
+			flexbox:  flexbox.New().HSpacing(0).Justify(flexbox.JustifySpaceAround),
+			size:     geom.Size{Width: 340, Height: 20},
+			children: []child{{pref: small}, {pref: small}},
+			expected: []geom.Rect{rect(60, 0, 50, 20), rect(230, 0, 50, 20)},
+		},
+		{
+			name:     "justify space evenly",
+			flexbox:  flexbox.New().HSpacing(0).Justify(flexbox.JustifySpaceEvenly),
+			size:     geom.Size{Width: 340, Height: 20},
+			children: []child{{pref: small}, {pref: small}},
+			expected: []geom.Rect{rect(80, 0, 50, 20), rect(210, 0, 50, 20)},
+		},
+		{
+			name:     "row reverse",
+			flexbox:  flexbox.New().HSpacing(0).Direction(flexbox.RowReverse),
+			size:     geom.Size{Width: 300, Height: 20},
+			children: []child{{pref: small}, {pref: large}},
+			expected: []geom.Rect{rect(250, 0, 50, 20), rect(150, 0, 100, 20)},
+		},
+		{
+			name:     "column",
+			flexbox:  flexbox.New().VSpacing(5).Direction(flexbox.Column),
+			size:     geom.Size{Width: 100, Height: 200},
+			children: []child{{pref: small}, {pref: large}},
+			expected: []geom.Rect{rect(0, 0, 100, 20), rect(0, 25, 100, 20)},
+		},
+		{
+			name:     "wrap",
+			flexbox:  flexbox.New().HSpacing(0).VSpacing(0).Wrap(true),
+			size:     geom.Size{Width: 250, Height: 40},
+			children: []child{{pref: large}, {pref: large}, {pref: large}},
+			expected: []geom.Rect{rect(0, 0, 100, 20), rect(100, 0, 100, 20), rect(0, 20, 100, 20)},
+		},
+		{
+			name:    "wrap with grow",
+			flexbox: flexbox.New().HSpacing(10).VSpacing(5).Wrap(true),
+			size:    geom.Size{Width: 250, Height: 45},
+			children: []child{
+				{pref: large, data: flexbox.NewData().Grow(1)},
+				{pref: large, data: flexbox.NewData().Grow(1)},
+				{pref: large, data: flexbox.NewData().Grow(1)},
+			},
+			expected: []geom.Rect{rect(0, 0, 120, 20), rect(130, 0, 120, 20), rect(0, 25, 250, 20)},
+		},
+	} {
+		children := make([]layout.Layoutable, len(tc.children))
+		items := make([]*layouttest.Item, len(tc.children))
+		for i, one := range tc.children {
+			items[i] = &layouttest.Item{Pref: one.pref, Max: one.max}
+			if one.data != nil {
+				one.data.Apply(items[i])
+			}
+			children[i] = items[i]
+		}
+		target := &layouttest.Item{Children: children}
+		tc.flexbox.Apply(target)
+		target.SetFrameRect(geom.Rect{Size: tc.size})
+		target.Layout.Layout()
+		for i, one := range items {
+			assert.Equal(t, tc.expected[i], one.Frame, "%s: child %d", tc.name, i)
+		}
+	}
+}
+
+func TestFlexboxSizes(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		flexbox *flexbox.Flexbox
+		hint    geom.Size
+		pref    geom.Size
+	}{
+		{
+			name:    "row",
+			flexbox: flexbox.New().HSpacing(10),
+			pref:    geom.Size{Width: 320, Height: 20},
+		},
+		{
+			name:    "column",
+			flexbox: flexbox.New().VSpacing(5).Direction(flexbox.Column),
+			pref:    geom.Size{Width: 100, Height: 70},
+		},
+		{
+			name:    "wrap",
+			flexbox: flexbox.New().HSpacing(10).VSpacing(5).Wrap(true),
+			hint:    geom.Size{Width: 250},
+			pref:    geom.Size{Width: 210, Height: 45},
+		},
+	} {
+		children := make([]layout.Layoutable, 3)
+		for i := range children {
+			children[i] = &layouttest.Item{Pref: geom.Size{Width: 100, Height: 20}}
+		}
+		target := &layouttest.Item{Children: children}
+		tc.flexbox.Apply(target)
+		_, pref, _ := target.Sizes(tc.hint)
+		assert.Equal(t, tc.pref, pref, tc.name)
+	}
+}
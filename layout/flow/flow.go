@@ -25,10 +25,11 @@ type Flow struct {
 }
 
 // New creates a new Flow layout. This layout arranges the children of its
-// target left-to-right, then top-to-bottom at their preferred sizes, if
-// possible. Each child of the target may have an Alignment set for its
-// LayoutData to control vertical positioning within the row. If not present,
-// Start is assumed.
+// target left-to-right (right-to-left if the target's direction is
+// RightToLeft), then top-to-bottom at their preferred sizes, if possible.
+// Each child of the target may have an Alignment set for its LayoutData to
+// control vertical positioning within the row. If not present, Start is
+// assumed.
 func New() *Flow {
 	return &Flow{
 		hSpacing: layout.DefaultHSpacing,
@@ -226,6 +227,7 @@ func (f *Flow) Layout() {
 }
 
 func (f *Flow) applyRects(children []layout.Layoutable, rects []geom.Rect, maxHeight float64) {
+	mirror := layout.MirrorFor(f.target)
 	for i, child := range children {
 		vAlign, ok := child.LayoutData().(align.Alignment)
 		if !ok {
@@ -242,6 +244,6 @@ func (f *Flow) applyRects(children []layout.Layoutable, rects []geom.Rect, maxHe
 			rects[i].Height = maxHeight
 		default: // same as Start
 		}
-		child.SetFrameRect(rects[i])
+		child.SetFrameRect(mirror.Rect(rects[i]))
 	}
 }
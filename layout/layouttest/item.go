@@ -0,0 +1,77 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+// Package layouttest provides helpers for testing layouts without needing
+// real panels.
+package layouttest
+
+import (
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ux/border"
+	"github.com/richardwilkes/ux/layout"
+)
+
+// Item is a minimal layout.Layoutable. Without a layout, it reports a zero
+// minimum size, Pref as its preferred size, and Max as its maximum size, or
+// layout.MaxSize(Pref) if Max is empty.
+type Item struct {
+	Layout   layout.Layout
+	Data     interface{}
+	Frame    geom.Rect
+	Pref     geom.Size
+	Max      geom.Size
+	Children []layout.Layoutable
+}
+
+// SetLayout implements layout.Layoutable.
+func (i *Item) SetLayout(lay layout.Layout) {
+	i.Layout = lay
+}
+
+// LayoutData implements layout.Layoutable.
+func (i *Item) LayoutData() interface{} {
+	return i.Data
+}
+
+// SetLayoutData implements layout.Layoutable.
+func (i *Item) SetLayoutData(data interface{}) {
+	i.Data = data
+}
+
+// Sizes implements layout.Layoutable.
+func (i *Item) Sizes(hint geom.Size) (min, pref, max geom.Size) {
+	if i.Layout != nil {
+		return i.Layout.Sizes(hint)
+	}
+	max = i.Max
+	if max.Width == 0 && max.Height == 0 {
+		max = layout.MaxSize(i.Pref)
+	}
+	return geom.Size{}, i.Pref, max
+}
+
+// Border implements layout.Layoutable.
+func (i *Item) Border() border.Border {
+	return nil
+}
+
+// FrameRect implements layout.Layoutable.
+func (i *Item) FrameRect() geom.Rect {
+	return i.Frame
+}
+
+// SetFrameRect implements layout.Layoutable.
+func (i *Item) SetFrameRect(rect geom.Rect) {
+	i.Frame = rect
+}
+
+// ChildrenForLayout implements layout.Layoutable.
+func (i *Item) ChildrenForLayout() []layout.Layoutable {
+	return i.Children
+}
@@ -0,0 +1,211 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package stack
+
+import (
+	"math"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ux"
+	"github.com/richardwilkes/ux/layout"
+)
+
+// Stack holds the stack layout information.
+type Stack struct {
+	target layout.Layoutable
+}
+
+// shown is set as the LayoutData of the visible children.
+type shown struct{}
+
+// hideable is implemented by children that can be kept out of the keyboard
+// focus while they aren't visible, such as ux.Panel.
+type hideable interface {
+	SetHidden(hidden bool) *ux.Panel
+}
+
+// New creates a new Stack layout. This layout places every child of its
+// target in the same rectangle, filling the target's content area. Only the
+// children marked as visible are given that rectangle; the rest are given an
+// empty frame, which prevents them from being drawn or receiving mouse
+// events, and are hidden, which keeps them out of the keyboard focus. Visible
+// children overlap in the target's child order. If no child has been marked
+// as visible, the first child is shown.
+//
+// The Stack manages the LayoutData of the target's children. Once applied,
+// retrieve the layout from the target's Layout() method to change which
+// children are visible.
+func New() *Stack {
+	return &Stack{}
+}
+
+// Apply the layout to the target. A copy is made of this layout and that is
+// applied to the target, so this layout may be applied to other targets.
+func (s *Stack) Apply(target layout.Layoutable) {
+	stack := *s
+	stack.target = target
+	target.SetLayout(&stack)
+	stack.relayout()
+}
+
+// ChildAdded is called by the target when a child is added to it, so that
+// the child can be hidden if it isn't visible.
+func (s *Stack) ChildAdded(child layout.Layoutable) {
+	s.ensureShown()
+	s.syncHidden(child)
+}
+
+// IsVisible returns true if the child is currently visible.
+func (s *Stack) IsVisible(child layout.Layoutable) bool {
+	s.ensureShown()
+	_, ok := child.LayoutData().(shown)
+	return ok
+}
+
+// Visible returns the children that are currently visible, in z-order.
+func (s *Stack) Visible() []layout.Layoutable {
+	s.ensureShown()
+	var list []layout.Layoutable
+	if s.target != nil {
+		for _, child := range s.target.ChildrenForLayout() {
+			if _, ok := child.LayoutData().(shown); ok {
+				list = append(list, child)
+			}
+		}
+	}
+	return list
+}
+
+// Select makes the child the only visible one.
+func (s *Stack) Select(child layout.Layoutable) *Stack {
+	if s.target != nil {
+		for _, one := range s.target.ChildrenForLayout() {
+			one.SetLayoutData(nil)
+		}
+	}
+	child.SetLayoutData(shown{})
+	s.relayout()
+	return s
+}
+
+// SetVisible sets whether the child is visible, leaving the visibility of the
+// other children unchanged.
+func (s *Stack) SetVisible(child layout.Layoutable, visible bool) *Stack {
+	s.ensureShown()
+	if visible {
+		child.SetLayoutData(shown{})
+	} else {
+		child.SetLayoutData(nil)
+	}
+	s.relayout()
+	return s
+}
+
+// ensureShown marks the first child as visible if no child is.
+func (s *Stack) ensureShown() {
+	if s.target != nil {
+		children := s.target.ChildrenForLayout()
+		for _, child := range children {
+			if _, ok := child.LayoutData().(shown); ok {
+				return
+			}
+		}
+		if len(children) != 0 {
+			children[0].SetLayoutData(shown{})
+			s.syncHidden(children[0])
+		}
+	}
+}
+
+func (s *Stack) relayout() {
+	if s.target != nil {
+		s.ensureShown()
+		s.updateHidden()
+		if r, ok := s.target.(interface{ MarkForLayoutAndRedraw() }); ok {
+			r.MarkForLayoutAndRedraw()
+		}
+	}
+}
+
+// updateHidden hides the children that aren't visible and unhides those that
+// are. This moves the keyboard focus out of any child that is hidden, so it
+// is done when the visible children change rather than during layout.
+func (s *Stack) updateHidden() {
+	for _, child := range s.target.ChildrenForLayout() {
+		s.syncHidden(child)
+	}
+}
+
+func (s *Stack) syncHidden(child layout.Layoutable) {
+	if h, ok := child.(hideable); ok {
+		_, visible := child.LayoutData().(shown)
+		h.SetHidden(!visible)
+	}
+}
+
+func (s *Stack) insets() geom.Insets {
+	if border := s.target.Border(); border != nil {
+		return border.Insets()
+	}
+	return geom.Insets{}
+}
+
+// Sizes implements Layout. The sizes of all children are considered, not just
+// the visible ones, so that switching between them doesn't alter the size of
+// the target.
+func (s *Stack) Sizes(hint geom.Size) (min, pref, max geom.Size) {
+	insets := s.insets()
+	if hint.Width > 0 {
+		hint.Width = math.Max(hint.Width-(insets.Left+insets.Right), 0)
+	}
+	if hint.Height > 0 {
+		hint.Height = math.Max(hint.Height-(insets.Top+insets.Bottom), 0)
+	}
+	for _, child := range s.target.ChildrenForLayout() {
+		cMin, cPref, cMax := child.Sizes(hint)
+		min.Width = math.Max(min.Width, cMin.Width)
+		min.Height = math.Max(min.Height, cMin.Height)
+		pref.Width = math.Max(pref.Width, cPref.Width)
+		pref.Height = math.Max(pref.Height, cPref.Height)
+		max.Width = math.Max(max.Width, cMax.Width)
+		max.Height = math.Max(max.Height, cMax.Height)
+	}
+	min.AddInsets(insets)
+	pref.AddInsets(insets)
+	max.AddInsets(insets)
+	if max.Width < pref.Width {
+		max.Width = pref.Width
+	}
+	if max.Height < pref.Height {
+		max.Height = pref.Height
+	}
+	return min, pref, max
+}
+
+// Layout implements Layout.
+func (s *Stack) Layout() {
+	insets := s.insets()
+	size := s.target.FrameRect().Size
+	rect := geom.Rect{
+		Point: geom.Point{X: insets.Left, Y: insets.Top},
+		Size: geom.Size{
+			Width:  math.Max(size.Width-(insets.Left+insets.Right), 0),
+			Height: math.Max(size.Height-(insets.Top+insets.Bottom), 0),
+		},
+	}
+	s.ensureShown()
+	for _, child := range s.target.ChildrenForLayout() {
+		if _, ok := child.LayoutData().(shown); ok {
+			child.SetFrameRect(rect)
+		} else {
+			child.SetFrameRect(geom.Rect{})
+		}
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ux/draw"
+)
+
+var (
+	layoutDebugging       bool
+	layoutDebugFrameInk   = draw.ARGB(0.6, 255, 0, 0)
+	layoutDebugBorderInk  = draw.ARGB(0.15, 255, 160, 0)
+	layoutDebugContentInk = draw.ARGB(0.6, 0, 128, 255)
+	layoutDebugHoverInk   = draw.ARGB(0.2, 0, 200, 0)
+	layoutDebugInfoInk    = draw.ARGB(0.85, 255, 255, 224)
+	layoutDebugTextInk    = draw.Black
+)
+
+// LayoutDebugging returns true if layout debugging is enabled for all
+// windows.
+func LayoutDebugging() bool {
+	return layoutDebugging
+}
+
+// SetLayoutDebugging sets whether layout debugging is enabled for all
+// windows. When enabled, each panel's frame, border insets and content area
+// are drawn over the window's contents, and the panel under the mouse is
+// highlighted along with its sizes and layout information.
+func SetLayoutDebugging(enabled bool) {
+	if layoutDebugging != enabled {
+		layoutDebugging = enabled
+		for _, w := range windowList {
+			w.MarkForRedraw()
+		}
+	}
+}
+
+// LayoutDebugging returns true if layout debugging is enabled for this
+// window, either directly or because it has been enabled for all windows.
+func (w *Window) LayoutDebugging() bool {
+	return layoutDebugging || w.layoutDebugging
+}
+
+// SetLayoutDebugging sets whether layout debugging is enabled for this
+// window. Enabling layout debugging for all windows via the global
+// SetLayoutDebugging() overrides this setting.
+func (w *Window) SetLayoutDebugging(enabled bool) {
+	if w.layoutDebugging != enabled {
+		w.layoutDebugging = enabled
+		w.MarkForRedraw()
+	}
+}
+
+func (w *Window) drawLayoutDebugging(gc draw.Context) {
+	gc.Save()
+	gc.SetStrokeWidth(1)
+	drawPanelLayoutDebugging(gc, &w.root.Panel)
+	gc.Restore()
+	if hover := w.lastMouseOverPanel; hover != nil && hover.Window() == w {
+		rect := hover.RectToRoot(hover.ContentRect(true))
+		gc.Rect(rect)
+		gc.Fill(layoutDebugHoverInk)
+		drawLayoutDebugInfo(gc, hover, rect, w.root.ContentRect(true))
+	}
+}
+
+func drawPanelLayoutDebugging(gc draw.Context, p *Panel) {
+	bounds := p.ContentRect(true)
+	if p.border != nil {
+		content := p.ContentRect(false)
+		gc.BeginPath()
+		gc.Rect(bounds)
+		gc.Rect(content)
+		gc.FillEvenOdd(layoutDebugBorderInk)
+		if content != bounds {
+			gc.Rect(insetForStroke(content))
+			gc.Stroke(layoutDebugContentInk)
+		}
+	}
+	gc.Rect(insetForStroke(bounds))
+	gc.Stroke(layoutDebugFrameInk)
+	for _, child := range p.children {
+		if !child.frame.IsEmpty() {
+			gc.Save()
+			gc.Translate(child.frame.X, child.frame.Y)
+			drawPanelLayoutDebugging(gc, child)
+			gc.Restore()
+		}
+	}
+}
+
+func insetForStroke(rect geom.Rect) geom.Rect {
+	rect.InsetUniform(0.5)
+	rect.Width = math.Max(rect.Width, 0)
+	rect.Height = math.Max(rect.Height, 0)
+	return rect
+}
+
+func drawLayoutDebugInfo(gc draw.Context, p *Panel, rect, bounds geom.Rect) {
+	lines := layoutDebugInfo(p)
+	font := draw.SmallSystemFont
+	lineHeight := font.Height()
+	var width float64
+	for _, line := range lines {
+		width = math.Max(width, font.Width(line))
+	}
+	const margin = 4
+	info := geom.Rect{Size: geom.Size{Width: width + margin*2, Height: lineHeight*float64(len(lines)) + margin*2}}
+	info.X = math.Max(bounds.X, math.Min(rect.X, bounds.Right()-info.Width))
+	info.Y = rect.Bottom() + margin
+	if info.Bottom() > bounds.Bottom() {
+		info.Y = math.Max(bounds.Y, rect.Y-(info.Height+margin))
+	}
+	gc.Rect(info)
+	gc.Fill(layoutDebugInfoInk)
+	gc.Rect(insetForStroke(info))
+	gc.Stroke(layoutDebugFrameInk)
+	y := info.Y + margin
+	for _, line := range lines {
+		gc.DrawString(info.X+margin, y, font, layoutDebugTextInk, line)
+		y += lineHeight
+	}
+}
+
+func layoutDebugInfo(p *Panel) []string {
+	min, pref, max := p.Sizes(geom.Size{})
+	lines := []string{
+		p.String(),
+		fmt.Sprintf("frame: %v", p.frame),
+		fmt.Sprintf("min: %v", min),
+		fmt.Sprintf("pref: %v", pref),
+		fmt.Sprintf("max: %v", max),
+	}
+	if p.border != nil {
+		lines = append(lines, fmt.Sprintf("insets: %v", p.border.Insets()))
+	}
+	if p.layoutData != nil {
+		lines = append(lines, fmt.Sprintf("layout data: %v", p.layoutData))
+	}
+	if d, ok := p.layout.(interface{ Dump() string }); ok {
+		lines = append(lines, "layout:")
+		for _, line := range strings.Split(d.Dump(), "\n") {
+			lines = append(lines, "  "+line)
+		}
+	}
+	return lines
+}
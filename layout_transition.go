@@ -0,0 +1,271 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"math"
+	"time"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ux/draw"
+	"github.com/richardwilkes/ux/ease"
+)
+
+// LayoutTransition holds the settings used to animate the changes a panel's
+// layout makes to the frames of its children.
+type LayoutTransition struct {
+	// Duration is the amount of time each transition takes. A value of zero
+	// or less disables animation.
+	Duration time.Duration
+	// Easing is the easing curve applied to the transition. If nil,
+	// ease.InOutQuad is used.
+	Easing ease.Func
+	// Fade causes children that are added to fade in and children that are
+	// removed to fade out.
+	Fade bool
+}
+
+type layoutTransitionState struct {
+	frames    map[*Panel]*frameTransition
+	fades     map[*Panel]time.Time
+	known     map[*Panel]bool
+	departing []*departingPanel
+	running   bool
+}
+
+type frameTransition struct {
+	from  geom.Rect
+	to    geom.Rect
+	start time.Time
+}
+
+type departingPanel struct {
+	panel *Panel
+	frame geom.Rect
+	start time.Time
+}
+
+// LayoutTransition returns the settings used to animate layout changes, if
+// any.
+func (p *Panel) LayoutTransition() *LayoutTransition {
+	return p.transition
+}
+
+// SetLayoutTransition sets the settings used to animate layout changes. Once
+// set, changes made by this panel's layout to the frames of its children
+// will be interpolated from their old to their new positions rather than
+// being applied immediately. Pass in nil to disable animation. Any
+// transitions currently in progress are completed immediately when the
+// settings are changed.
+func (p *Panel) SetLayoutTransition(transition *LayoutTransition) *Panel {
+	p.finishLayoutTransitions()
+	if transition != nil {
+		t := *transition
+		transition = &t
+	}
+	p.transition = transition
+	return p
+}
+
+func (p *Panel) layoutTransitionEnabled() bool {
+	return p.transition != nil && p.transition.Duration > 0
+}
+
+func (p *Panel) layoutWithTransition() {
+	before := make(map[*Panel]geom.Rect, len(p.children))
+	for _, child := range p.children {
+		before[child] = child.frame
+	}
+	p.layout.Layout()
+	initial := p.transitionState == nil
+	if initial {
+		p.transitionState = &layoutTransitionState{
+			frames: make(map[*Panel]*frameTransition),
+			fades:  make(map[*Panel]time.Time),
+			known:  make(map[*Panel]bool),
+		}
+	}
+	s := p.transitionState
+	now := time.Now()
+	known := make(map[*Panel]bool, len(p.children))
+	for _, child := range p.children {
+		known[child] = true
+		if initial {
+			continue
+		}
+		if !s.known[child] {
+			if p.transition.Fade {
+				s.fades[child] = now
+			}
+			continue
+		}
+		to := child.frame
+		if from := before[child]; from != to {
+			s.frames[child] = &frameTransition{from: from, to: to, start: now}
+			child.SetFrameRect(from)
+		}
+	}
+	for child := range s.frames {
+		if !known[child] {
+			delete(s.frames, child)
+		}
+	}
+	for child := range s.fades {
+		if !known[child] {
+			delete(s.fades, child)
+		}
+	}
+	s.known = known
+	p.scheduleLayoutTransition()
+}
+
+func (p *Panel) childRemovedDuringTransition(child *Panel) {
+	if s := p.transitionState; s != nil {
+		delete(s.frames, child)
+		delete(s.fades, child)
+		delete(s.known, child)
+		if p.layoutTransitionEnabled() && p.transition.Fade && !child.frame.IsEmpty() {
+			s.departing = append(s.departing, &departingPanel{
+				panel: child,
+				frame: child.frame,
+				start: time.Now(),
+			})
+			p.scheduleLayoutTransition()
+		}
+	}
+}
+
+func (p *Panel) scheduleLayoutTransition() {
+	if s := p.transitionState; s != nil && !s.running && (len(s.frames) != 0 || len(s.fades) != 0 || len(s.departing) != 0) {
+		s.running = true
+		AddFrameCallback(func(now time.Time) bool { return p.stepLayoutTransition(s, now) })
+	}
+}
+
+// stepLayoutTransition is called by the frame clock, returning true while
+// there is still work left for the transition to do.
+func (p *Panel) stepLayoutTransition(s *layoutTransitionState, now time.Time) bool {
+	if s != p.transitionState {
+		return false
+	}
+	if !p.layoutTransitionEnabled() {
+		s.running = false
+		p.finishLayoutTransitions()
+		return false
+	}
+	for child, ft := range s.frames {
+		t := p.transitionProgress(ft.start, now)
+		if t >= 1 {
+			child.SetFrameRect(ft.to)
+			delete(s.frames, child)
+		} else {
+			child.SetFrameRect(interpolateRect(ft.from, ft.to, t))
+		}
+	}
+	for child, start := range s.fades {
+		if p.transitionProgress(start, now) >= 1 {
+			delete(s.fades, child)
+		}
+	}
+	i := 0
+	for _, d := range s.departing {
+		if d.panel.parent == nil && p.transitionProgress(d.start, now) < 1 {
+			s.departing[i] = d
+			i++
+		}
+	}
+	for j := i; j < len(s.departing); j++ {
+		s.departing[j] = nil
+	}
+	s.departing = s.departing[:i]
+	p.MarkForRedraw()
+	s.running = len(s.frames) != 0 || len(s.fades) != 0 || len(s.departing) != 0
+	return s.running
+}
+
+func (p *Panel) finishLayoutTransitions() {
+	if s := p.transitionState; s != nil {
+		p.transitionState = nil
+		for child, ft := range s.frames {
+			child.SetFrameRect(ft.to)
+		}
+		p.MarkForRedraw()
+	}
+}
+
+// transitionProgress returns the eased progress of a transition that began
+// at start. Any value of 1 or greater indicates completion.
+func (p *Panel) transitionProgress(start, now time.Time) float64 {
+	elapsed := now.Sub(start)
+	if elapsed >= p.transition.Duration {
+		return 1
+	}
+	t := float64(elapsed) / float64(p.transition.Duration)
+	if p.transition.Easing != nil {
+		return p.transition.Easing(t)
+	}
+	return ease.InOutQuad(t)
+}
+
+// childOpacity returns the opacity a child should be drawn with while it is
+// fading in.
+func (p *Panel) childOpacity(child *Panel) float64 {
+	if s := p.transitionState; s != nil && p.layoutTransitionEnabled() {
+		if start, ok := s.fades[child]; ok {
+			return clampOpacity(p.transitionProgress(start, time.Now()))
+		}
+	}
+	return 1
+}
+
+func (p *Panel) drawDeparting(gc draw.Context, dirty geom.Rect, inLiveResize bool) {
+	if s := p.transitionState; s != nil && p.layoutTransitionEnabled() {
+		now := time.Now()
+		for _, d := range s.departing {
+			if d.panel.parent != nil {
+				continue
+			}
+			adjusted := dirty
+			adjusted.Intersect(d.frame)
+			if !adjusted.IsEmpty() {
+				gc.Save()
+				gc.SetOpacity(clampOpacity(1 - p.transitionProgress(d.start, now)))
+				gc.Translate(d.frame.X, d.frame.Y)
+				adjusted.X -= d.frame.X
+				adjusted.Y -= d.frame.Y
+				d.panel.Draw(gc, adjusted, inLiveResize)
+				gc.Restore()
+			}
+		}
+	}
+}
+
+func clampOpacity(opacity float64) float64 {
+	if opacity < 0 {
+		return 0
+	}
+	if opacity > 1 {
+		return 1
+	}
+	return opacity
+}
+
+func interpolateRect(from, to geom.Rect, t float64) geom.Rect {
+	return geom.Rect{
+		Point: geom.Point{
+			X: from.X + (to.X-from.X)*t,
+			Y: from.Y + (to.Y-from.Y)*t,
+		},
+		Size: geom.Size{
+			Width:  math.Max(from.Width+(to.Width-from.Width)*t, 0),
+			Height: math.Max(from.Height+(to.Height-from.Height)*t, 0),
+		},
+	}
+}
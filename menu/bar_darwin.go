@@ -27,6 +27,13 @@ func osMenuBarForWindow(_ *ux.Window, updater Updater) (bar *Bar, isGlobal, isFi
 	return menuBar, true, first
 }
 
+func osAllMenuBars() []*Bar {
+	if menuBar == nil {
+		return nil
+	}
+	return []*Bar{menuBar}
+}
+
 func osMenuBarHeightInWindow() float64 {
 	return 0
 }
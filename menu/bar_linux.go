@@ -9,21 +9,263 @@
 
 package menu
 
-import "github.com/richardwilkes/ux"
+import (
+	"math"
+	"unicode"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ux"
+	"github.com/richardwilkes/ux/draw"
+	"github.com/richardwilkes/ux/keys"
+	"github.com/richardwilkes/ux/layout"
+)
+
+const (
+	barHMargin = 8
+	barVMargin = 4
+)
+
+var menuBars = make(map[*ux.Window]*Bar)
+
+type barPanel struct {
+	ux.Panel
+	wnd  *ux.Window
+	menu *menuData
+}
 
 func osMenuBarForWindow(wnd *ux.Window, updater Updater) (bar *Bar, isGlobal, isFirst bool) {
-	if !wnd.IsValid() {
+	if !wnd.IsValid() || !wnd.HasInternalMenu() {
 		return nil, false, false
 	}
-	// RAW: Implement
-	return nil, false, false
+	if bar, exists := menuBars[wnd]; exists {
+		return bar, false, false
+	}
+	if ux.MenuKeyDownCallback == nil {
+		ux.MenuKeyDownCallback = osiHandleMenuKeyDown
+	}
+	bar = &Bar{bar: New("", updater)}
+	menuBars[wnd] = bar
+	b := newBarPanel(wnd, bar.bar.native)
+	wnd.SetMenuBar(b.AsPanel())
+	b.AddWindowExitHook(func() {
+		delete(menuBars, wnd)
+		b.menu.bar = nil
+	})
+	return bar, false, true
+}
+
+func osAllMenuBars() []*Bar {
+	bars := make([]*Bar, 0, len(menuBars))
+	for _, bar := range menuBars {
+		bars = append(bars, bar)
+	}
+	return bars
 }
 
 func osMenuBarHeightInWindow() float64 {
-	// RAW: Implement
-	return 0
+	return math.Ceil(draw.MenuFont.Height()) + barVMargin*2 + 1
 }
 
 func (bar *Bar) osInsertMenu(atIndex, id int, menu *Menu) {
-	// RAW: Implement
+	bar.bar.osInsertMenu(atIndex, id, menu)
+}
+
+// -- From here down are specific to Linux
+
+func newBarPanel(wnd *ux.Window, menu *menuData) *barPanel {
+	b := &barPanel{
+		wnd:  wnd,
+		menu: menu,
+	}
+	b.InitTypeAndID(b)
+	menu.bar = b
+	b.SetSizer(b.sizes)
+	b.DrawCallback = b.draw
+	b.MouseDownCallback = b.mouseDown
+	b.MouseDragCallback = b.mouseDrag
+	b.MouseUpCallback = b.mouseUp
+	return b
+}
+
+func (b *barPanel) sizes(hint geom.Size) (min, pref, max geom.Size) {
+	pref.Height = osMenuBarHeightInWindow()
+	for _, one := range b.titleRects() {
+		pref.Width += one.Width
+	}
+	min.Height = pref.Height
+	max.Width = math.Max(layout.DefaultMaxSize, pref.Width)
+	max.Height = pref.Height
+	return min, pref, max
+}
+
+// mnemonics returns the mnemonics for the menus in the bar.
+func (b *barPanel) mnemonics() []mnemonic {
+	titles := make([]string, len(b.menu.items))
+	for i, item := range b.menu.items {
+		titles[i] = item.title
+	}
+	return mnemonicsFor(titles)
+}
+
+// titleRects returns the rectangles occupied by each menu title.
+func (b *barPanel) titleRects() []geom.Rect {
+	height := osMenuBarHeightInWindow() - 1
+	var x float64
+	rects := make([]geom.Rect, len(b.menu.items))
+	for i, m := range b.mnemonics() {
+		width := math.Ceil(draw.MenuFont.Width(m.text)) + barHMargin*2
+		rects[i] = geom.Rect{Point: geom.Point{X: x}, Size: geom.Size{Width: width, Height: height}}
+		x += width
+	}
+	return rects
+}
+
+// titleAt returns the index of the menu title at the location, or -1.
+func (b *barPanel) titleAt(where geom.Point) int {
+	for i, r := range b.titleRects() {
+		if r.ContainsPoint(where) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (b *barPanel) draw(gc draw.Context, dirty geom.Rect, inLiveResize bool) {
+	rect := b.ContentRect(true)
+	gc.Rect(rect)
+	gc.Fill(draw.WindowBackgroundColor)
+	gc.Rect(geom.Rect{Point: geom.Point{X: rect.X, Y: rect.Bottom() - 1}, Size: geom.Size{Width: rect.Width, Height: 1}})
+	gc.Fill(draw.SeparatorColor)
+	current := -1
+	showMnemonics := false
+	if t, ok := trackers[b.wnd]; ok && t.bar == b {
+		current = t.barIndex
+		showMnemonics = t.keyboard
+	}
+	font := draw.MenuFont
+	mnemonics := b.mnemonics()
+	for i, r := range b.titleRects() {
+		ink := draw.LabelColor
+		if i == current {
+			gc.Rect(r)
+			gc.Fill(draw.SelectedContentBackgroundColor)
+			ink = draw.SelectedMenuItemTextColor
+		}
+		drawMnemonicText(gc, r.X+barHMargin, r.Y+(r.Height-font.Height())/2, font, ink, mnemonics[i], showMnemonics)
+	}
+}
+
+func (b *barPanel) mouseDown(where geom.Point, button, clickCount int, mod keys.Modifiers) bool {
+	if index := b.titleAt(where); index >= 0 {
+		newTracker(b.wnd, b).openBarMenu(index, false)
+		return true
+	}
+	return false
+}
+
+// The press that opens a menu is delivered to the bar, so the rest of that
+// gesture is forwarded on to the tracker.
+
+func (b *barPanel) mouseDrag(where geom.Point, button int, mod keys.Modifiers) {
+	if t, ok := trackers[b.wnd]; ok && t.bar == b {
+		t.mouseDrag(b.PointToRoot(where), button, mod)
+	}
+}
+
+func (b *barPanel) mouseUp(where geom.Point, button int, mod keys.Modifiers) {
+	if t, ok := trackers[b.wnd]; ok && t.bar == b {
+		t.mouseUp(b.PointToRoot(where), button, mod)
+	}
+}
+
+// openForKey opens the menu whose mnemonic matches the character. Returns
+// true if one was found.
+func (b *barPanel) openForKey(ch rune) bool {
+	ch = unicode.ToLower(ch)
+	for i, m := range b.mnemonics() {
+		if m.ch == ch {
+			newTracker(b.wnd, b).openBarMenu(i, true)
+			return true
+		}
+	}
+	return false
+}
+
+// osiHandleMenuKeyDown is installed as the ux.MenuKeyDownCallback and
+// handles the keys that open a window's menus, as well as the hot keys of
+// the menu items. Windows without a menu bar of their own use the hot keys of
+// the first window that has one.
+func osiHandleMenuKeyDown(wnd *ux.Window, keyCode int, ch rune, mod keys.Modifiers, repeat bool) bool {
+	mod &= keys.NonStickyModifiers
+	if bar, exists := menuBars[wnd]; exists && len(bar.bar.native.items) != 0 {
+		b := bar.bar.native.bar
+		switch {
+		case keyCode == keys.F10.Code && mod == 0:
+			newTracker(wnd, b).openBarMenu(0, true)
+			return true
+		case mod == keys.OptionModifier && ch != 0 && !repeat:
+			if b.openForKey(ch) {
+				return true
+			}
+		}
+	}
+	bar := osiMenuBarForHotKeys(wnd)
+	if bar == nil {
+		return false
+	}
+	if !couldBeHotKey(keyCode, mod) {
+		// Leave keys used for typing and editing, such as Backspace, to the
+		// focused panel. The updater may also rebuild the menus, so it
+		// shouldn't be run for every key typed.
+		return false
+	}
+	bar.bar.native.update()
+	if item := bar.bar.native.itemForHotKey(keyCode, mod); item != nil && item.enabled() {
+		item.invoke()
+		return true
+	}
+	return false
+}
+
+// couldBeHotKey returns true if a modifier other than shift is held down or
+// the key is a function key.
+func couldBeHotKey(keyCode int, mod keys.Modifiers) bool {
+	if mod&^keys.ShiftModifier != 0 {
+		return true
+	}
+	switch keyCode {
+	case keys.F1.Code, keys.F2.Code, keys.F3.Code, keys.F4.Code, keys.F5.Code, keys.F6.Code, keys.F7.Code,
+		keys.F8.Code, keys.F9.Code, keys.F10.Code, keys.F11.Code, keys.F12.Code, keys.F13.Code, keys.F14.Code,
+		keys.F15.Code:
+		return true
+	default:
+		return false
+	}
+}
+
+func osiMenuBarForHotKeys(wnd *ux.Window) *Bar {
+	if bar, exists := menuBars[wnd]; exists {
+		return bar
+	}
+	for _, one := range ux.Windows() {
+		if bar, exists := menuBars[one]; exists {
+			return bar
+		}
+	}
+	return nil
+}
+
+// itemForHotKey returns the item within the menu or its sub-menus that uses
+// the key and modifiers as its hot key, or nil.
+func (m *menuData) itemForHotKey(keyCode int, mod keys.Modifiers) *itemData {
+	for _, item := range m.items {
+		if item.subMenu != nil {
+			if found := item.subMenu.itemForHotKey(keyCode, mod); found != nil {
+				return found
+			}
+		} else if item.key != nil && item.key.Code == keyCode && item.modifiers&keys.NonStickyModifiers == mod {
+			return item
+		}
+	}
+	return nil
 }
@@ -54,6 +54,14 @@ func osMenuBarForWindow(wnd *ux.Window, updater Updater) (bar *Bar, isGlobal, is
 	return nil, false, false
 }
 
+func osAllMenuBars() []*Bar {
+	bars := make([]*Bar, 0, len(menuBarMap))
+	for _, bar := range menuBarMap {
+		bars = append(bars, bar)
+	}
+	return bars
+}
+
 func osMenuBarHeightInWindow() float64 {
 	return float64(win32.GetSystemMetrics(win32.SM_CYMENU))
 }
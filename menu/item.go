@@ -9,7 +9,10 @@
 
 package menu
 
-import "github.com/richardwilkes/ux/widget/checkbox/state"
+import (
+	"github.com/richardwilkes/ux/keys"
+	"github.com/richardwilkes/ux/widget/checkbox/state"
+)
 
 // ItemValidator is a function called to validate a menu item.
 type ItemValidator func(item *Item) bool
@@ -82,3 +85,9 @@ func (item *Item) CheckState() state.State {
 func (item *Item) SetCheckState(s state.State) {
 	item.osSetCheckState(s)
 }
+
+// setHotKey changes the item's hot key, leaving everything else about it,
+// such as its check state and callbacks, as it was.
+func (item *Item) setHotKey(key *keys.Key, keyModifiers keys.Modifiers) {
+	item.osSetHotKey(key, keyModifiers)
+}
@@ -11,11 +11,21 @@ package menu
 
 import (
 	"github.com/richardwilkes/macos/ns"
+	"github.com/richardwilkes/ux/keys"
 	"github.com/richardwilkes/ux/widget/checkbox/state"
 )
 
 type osItem = *ns.MenuItem
 
+type itemCallbacks struct {
+	validator ItemValidator
+	handler   ItemHandler
+}
+
+// callbacks holds the validator and handler given for each item, as the
+// native item can't change its key equivalent and must be replaced to do so.
+var callbacks = make(map[ns.MenuItemNative]itemCallbacks)
+
 func (item *Item) osIsSame(other *Item) bool {
 	return item.native.Native() == other.native.Native()
 }
@@ -75,3 +85,17 @@ func (item *Item) osSetCheckState(s state.State) {
 		item.native.SetState(ns.MenuItemStateOff)
 	}
 }
+
+func (item *Item) osSetHotKey(key *keys.Key, keyModifiers keys.Modifiers) {
+	cb, exists := callbacks[item.native.Native()]
+	menu := item.osMenu()
+	if !exists || menu == nil {
+		return
+	}
+	index := item.Index()
+	checkState := item.osCheckState()
+	menu.osRemoveItem(index)
+	replacement := menu.osInsertItem(index, item.osID(), item.osTitle(), key, keyModifiers, cb.validator, cb.handler)
+	replacement.osSetCheckState(checkState)
+	item.native = replacement.native
+}
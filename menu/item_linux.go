@@ -9,49 +9,107 @@
 
 package menu
 
-import "github.com/richardwilkes/ux/widget/checkbox/state"
+import (
+	"github.com/richardwilkes/ux/keys"
+	"github.com/richardwilkes/ux/widget/checkbox/state"
+)
 
-type osItem = int
+type itemData struct {
+	menu       *menuData
+	subMenu    *menuData
+	key        *keys.Key
+	validator  ItemValidator
+	handler    ItemHandler
+	title      string
+	id         int
+	modifiers  keys.Modifiers
+	checkState state.State
+	separator  bool
+}
+
+type osItem = *itemData
 
 func (item *Item) osIsSame(other *Item) bool {
-	// RAW: Implement
 	return item.native == other.native
 }
 
 func (item *Item) osMenu() *Menu {
-	// RAW: Implement
-	return nil
+	if item.native.menu == nil {
+		return nil
+	}
+	return &Menu{native: item.native.menu}
 }
 
 func (item *Item) osIsSeparator() bool {
-	// RAW: Implement
-	return false
+	return item.native.separator
 }
 
 func (item *Item) osID() int {
-	// RAW: Implement
-	return 0
+	return item.native.id
 }
 
 func (item *Item) osTitle() string {
-	// RAW: Implement
-	return ""
+	return item.native.title
 }
 
 func (item *Item) osSetTitle(title string) {
-	// RAW: Implement
+	if item.native.title != title {
+		item.native.title = title
+		if item.native.menu != nil {
+			item.native.menu.changed()
+		}
+	}
 }
 
 func (item *Item) osSubMenu() *Menu {
-	// RAW: Implement
-	return nil
+	if item.native.subMenu == nil {
+		return nil
+	}
+	return &Menu{native: item.native.subMenu}
 }
 
 func (item *Item) osCheckState() state.State {
-	// RAW: Implement
-	return state.Off
+	return item.native.checkState
 }
 
 func (item *Item) osSetCheckState(s state.State) {
-	// RAW: Implement
+	item.native.checkState = s
+}
+
+func (item *Item) osSetHotKey(key *keys.Key, keyModifiers keys.Modifiers) {
+	if item.native.key != key || item.native.modifiers != keyModifiers {
+		item.native.key = key
+		item.native.modifiers = keyModifiers
+		if item.native.menu != nil {
+			item.native.menu.changed()
+		}
+	}
+}
+
+// -- From here down are specific to Linux
+
+// enabled returns true if the item can currently be chosen.
+func (d *itemData) enabled() bool {
+	if d.separator {
+		return false
+	}
+	if d.subMenu != nil {
+		return true
+	}
+	return d.validator == nil || d.validator(&Item{native: d})
+}
+
+// hotKeyText returns the text used to describe the item's hot key.
+func (d *itemData) hotKeyText() string {
+	if d.key == nil {
+		return ""
+	}
+	return d.modifiers.String() + d.key.Name
+}
+
+// invoke calls the item's handler.
+func (d *itemData) invoke() {
+	if d.handler != nil {
+		d.handler(&Item{native: d})
+	}
 }
@@ -70,3 +70,10 @@ func (item *Item) osCheckState() state.State {
 func (item *Item) osSetCheckState(s state.State) {
 	// RAW: Implement
 }
+
+func (item *Item) osSetHotKey(key *keys.Key, keyModifiers keys.Modifiers) {
+	// RAW: Implement
+	// item.native.key = key
+	// item.native.modifiers = keyModifiers
+	// osiMarkAllForMenuKeyRefresh()
+}
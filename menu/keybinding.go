@@ -0,0 +1,45 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package menu
+
+import (
+	"github.com/richardwilkes/ux/action"
+	"github.com/richardwilkes/ux/keybinding"
+)
+
+func init() {
+	keybinding.AddChangeListener(updateActionItems)
+}
+
+// updateActionItems updates the hot keys of the items in the menu bars that
+// were created for registered actions, so that they reflect the key bindings
+// currently in effect.
+func updateActionItems() {
+	for _, bar := range osAllMenuBars() {
+		bar.bar.updateActionItems()
+	}
+}
+
+func (menu *Menu) updateActionItems() {
+	count := menu.Count()
+	for i := 0; i < count; i++ {
+		item := menu.ItemAtIndex(i)
+		if item == nil || item.IsSeparator() {
+			continue
+		}
+		if subMenu := item.SubMenu(); subMenu != nil {
+			subMenu.updateActionItems()
+			continue
+		}
+		if a := action.Lookup(item.ID()); a != nil {
+			item.setHotKey(keybinding.HotKey(a))
+		}
+	}
+}
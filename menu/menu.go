@@ -14,6 +14,7 @@ import (
 	"github.com/richardwilkes/ux"
 	"github.com/richardwilkes/ux/action"
 	"github.com/richardwilkes/ux/ids"
+	"github.com/richardwilkes/ux/keybinding"
 	"github.com/richardwilkes/ux/keys"
 )
 
@@ -82,9 +83,12 @@ func (menu *Menu) InsertSeparatorIfNeeded(atIndex int) {
 }
 
 // InsertActionItem inserts a menu item using the action at the specified item
-// index within this menu. Pass in a negative index to append to the end.
+// index within this menu. Pass in a negative index to append to the end. The
+// item's hot key is taken from the key bindings currently in effect for the
+// action.
 func (menu *Menu) InsertActionItem(atIndex int, cmd action.Action) *Item {
-	return menu.InsertItem(atIndex, cmd.ID(), cmd.Title(), cmd.HotKey(), cmd.HotKeyModifiers(), func(item *Item) bool { return cmd.Enabled(item) }, func(item *Item) { cmd.Execute(item) })
+	key, mod := keybinding.HotKey(cmd)
+	return menu.InsertItem(atIndex, cmd.ID(), cmd.Title(), key, mod, func(item *Item) bool { return cmd.Enabled(item) }, func(item *Item) { action.Perform(cmd, item) })
 }
 
 // InsertActionItemForContextMenu inserts a menu item for a context menu using
@@ -94,7 +98,7 @@ func (menu *Menu) InsertActionItem(atIndex int, cmd action.Action) *Item {
 func (menu *Menu) InsertActionItemForContextMenu(atIndex int, cmd action.Action) *Item {
 	id := cmd.ID() | ids.ContextMenuIDFlag
 	if cmd.Enabled(nil) {
-		return menu.InsertItem(atIndex, id, cmd.Title(), nil, 0, func(item *Item) bool { return cmd.Enabled(item) }, func(item *Item) { cmd.Execute(item) })
+		return menu.InsertItem(atIndex, id, cmd.Title(), nil, 0, func(item *Item) bool { return cmd.Enabled(item) }, func(item *Item) { action.Perform(cmd, item) })
 	}
 	return nil
 }
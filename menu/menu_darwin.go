@@ -56,6 +56,7 @@ func (menu *Menu) osInsertItem(atIndex, id int, title string, key *keys.Key, key
 		keyCodeStr = key.RuneStr()
 	}
 	item := ns.MenuItemInitWithTitleActionKeyEquivalent(id, title, keyCodeStr, int(keyModifiers)<<16, func(item *ns.MenuItem) bool { return validator(&Item{native: item}) }, func(item *ns.MenuItem) { handler(&Item{native: item}) })
+	callbacks[item.Native()] = itemCallbacks{validator: validator, handler: handler}
 	menu.osiInsertItemAtIndex(item, atIndex)
 	return &Item{native: item}
 }
@@ -75,6 +76,7 @@ func (menu *Menu) osInsertMenu(atIndex, id int, subMenu *Menu) {
 }
 
 func (menu *Menu) osRemoveItem(index int) {
+	delete(callbacks, menu.native.ItemAtIndex(index).Native())
 	menu.native.RemoveItem(index)
 }
 
@@ -15,54 +15,155 @@ import (
 	"github.com/richardwilkes/ux/keys"
 )
 
-type osMenu = int
+type menuData struct {
+	title    string
+	updater  Updater
+	items    []*itemData
+	owner    *itemData // The item this menu is attached to, if any
+	bar      *barPanel // Only set for the root menu of a menu bar
+	disposed bool
+}
+
+type osMenu = *menuData
 
 func osNewMenu(title string, updater Updater) osMenu {
-	// RAW: Implement
-	return 0
+	return &menuData{
+		title:   title,
+		updater: updater,
+	}
 }
 
 func (menu *Menu) osIsSame(other *Menu) bool {
-	// RAW: Implement
 	return menu.native == other.native
 }
 
 func (menu *Menu) osItemAtIndex(index int) *Item {
-	// RAW: Implement
-	return nil
+	if index < 0 || index >= len(menu.native.items) {
+		return nil
+	}
+	return &Item{native: menu.native.items[index]}
 }
 
 func (menu *Menu) osInsertSeparator(atIndex int) {
-	// RAW: Implement
+	menu.native.insert(atIndex, &itemData{separator: true})
 }
 
 func (menu *Menu) osInsertItem(atIndex, id int, title string, key *keys.Key, keyModifiers keys.Modifiers, validator ItemValidator, handler ItemHandler) *Item {
-	// RAW: Implement
-	return nil
+	item := &itemData{
+		id:        id,
+		title:     title,
+		key:       key,
+		modifiers: keyModifiers,
+		validator: validator,
+		handler:   handler,
+	}
+	menu.native.insert(atIndex, item)
+	return &Item{native: item}
 }
 
 func (menu *Menu) osInsertNewMenu(atIndex, id int, title string, updater Updater) *Menu {
-	// RAW: Implement
-	return nil
+	subMenu := New(title, updater)
+	menu.osInsertMenu(atIndex, id, subMenu)
+	return subMenu
 }
 
 func (menu *Menu) osInsertMenu(atIndex, id int, subMenu *Menu) {
-	// RAW: Implement
+	item := &itemData{
+		id:      id,
+		title:   subMenu.native.title,
+		subMenu: subMenu.native,
+	}
+	subMenu.native.owner = item
+	menu.native.insert(atIndex, item)
 }
 
 func (menu *Menu) osRemoveItem(index int) {
-	// RAW: Implement
+	m := menu.native
+	item := m.items[index]
+	copy(m.items[index:], m.items[index+1:])
+	m.items[len(m.items)-1] = nil
+	m.items = m.items[:len(m.items)-1]
+	item.menu = nil
+	if item.subMenu != nil {
+		item.subMenu.owner = nil
+	}
+	m.changed()
 }
 
 func (menu *Menu) osItemCount() int {
-	// RAW: Implement
-	return 0
+	return len(menu.native.items)
 }
 
 func (menu *Menu) osPopup(wnd *ux.Window, where geom.Rect, currentIndex int) {
-	// RAW: Implement
+	if _, exists := trackers[wnd]; !exists && wnd.Overlay() == nil {
+		newTracker(wnd, nil).popup(menu.native, where, currentIndex)
+	}
 }
 
 func (menu *Menu) osDispose() {
-	// RAW: Implement
+	m := menu.native
+	if m.isOpen() {
+		// A popup doesn't block on this platform, so the disposal has to wait
+		// until the menu has been closed.
+		m.disposed = true
+		return
+	}
+	m.dispose()
+}
+
+// -- From here down are specific to Linux
+
+func (m *menuData) insert(atIndex int, item *itemData) {
+	if atIndex < 0 || atIndex > len(m.items) {
+		atIndex = len(m.items)
+	}
+	m.items = append(m.items, nil)
+	copy(m.items[atIndex+1:], m.items[atIndex:])
+	m.items[atIndex] = item
+	item.menu = m
+	m.changed()
+}
+
+func (m *menuData) dispose() {
+	for _, item := range m.items {
+		item.menu = nil
+		if item.subMenu != nil {
+			item.subMenu.dispose()
+		}
+	}
+	m.items = nil
+	m.updater = nil
+}
+
+// root returns the outermost menu this menu is attached to.
+func (m *menuData) root() *menuData {
+	for m.owner != nil && m.owner.menu != nil {
+		m = m.owner.menu
+	}
+	return m
+}
+
+// isOpen returns true if the menu is currently being shown.
+func (m *menuData) isOpen() bool {
+	for _, t := range trackers {
+		if t.isShowing(m) {
+			return true
+		}
+	}
+	return false
+}
+
+// changed should be called whenever the menu's content changes, so that any
+// menu bar it is part of can be updated.
+func (m *menuData) changed() {
+	if bar := m.root().bar; bar != nil {
+		bar.MarkForLayoutAndRedraw()
+	}
+}
+
+// update runs the updater for the menu, if any.
+func (m *menuData) update() {
+	if m.updater != nil {
+		m.updater(&Menu{native: m})
+	}
 }
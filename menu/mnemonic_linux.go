@@ -0,0 +1,96 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package menu
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ux/draw"
+)
+
+type mnemonic struct {
+	text  string // The title with any mnemonic marker removed
+	index int    // The byte index of the mnemonic within text, or -1
+	ch    rune   // The lower-cased mnemonic, or 0
+}
+
+// mnemonicsFor determines the mnemonics for a set of titles. A title may
+// choose its mnemonic by placing an '&' before it, with "&&" producing a
+// literal '&'. Titles that don't are given the first letter or digit not
+// already in use by another title in the set.
+func mnemonicsFor(titles []string) []mnemonic {
+	result := make([]mnemonic, len(titles))
+	used := make(map[rune]bool)
+	for i, title := range titles {
+		result[i] = parseMnemonic(title)
+		if result[i].ch != 0 {
+			used[result[i].ch] = true
+		}
+	}
+	for i := range result {
+		if result[i].ch != 0 {
+			continue
+		}
+		for j, ch := range result[i].text {
+			if unicode.IsLetter(ch) || unicode.IsDigit(ch) {
+				if lower := unicode.ToLower(ch); !used[lower] {
+					used[lower] = true
+					result[i].ch = lower
+					result[i].index = j
+					break
+				}
+			}
+		}
+	}
+	return result
+}
+
+func parseMnemonic(title string) mnemonic {
+	m := mnemonic{index: -1}
+	var buffer strings.Builder
+	runes := []rune(title)
+	for i := 0; i < len(runes); i++ {
+		ch := runes[i]
+		if ch == '&' && i+1 < len(runes) {
+			i++
+			ch = runes[i]
+			if ch != '&' && m.ch == 0 {
+				m.index = buffer.Len()
+				m.ch = unicode.ToLower(ch)
+			}
+		}
+		buffer.WriteRune(ch)
+	}
+	m.text = buffer.String()
+	return m
+}
+
+// drawMnemonicText draws the text of the mnemonic with its top-left corner at
+// x, y, optionally underlining the mnemonic character.
+func drawMnemonicText(gc draw.Context, x, y float64, font *draw.Font, ink draw.Ink, m mnemonic, underline bool) {
+	gc.DrawString(x, y, font, ink, m.text)
+	if underline && m.index >= 0 {
+		_, size := utf8.DecodeRuneInString(m.text[m.index:])
+		gc.Rect(geom.Rect{
+			Point: geom.Point{
+				X: x + font.Width(m.text[:m.index]),
+				Y: y + font.Ascent() + 1,
+			},
+			Size: geom.Size{
+				Width:  font.Width(m.text[m.index : m.index+size]),
+				Height: 1,
+			},
+		})
+		gc.Fill(ink)
+	}
+}
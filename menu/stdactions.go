@@ -0,0 +1,164 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package menu
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/richardwilkes/toolbox"
+	"github.com/richardwilkes/toolbox/cmdline"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/ux"
+	"github.com/richardwilkes/ux/action"
+	"github.com/richardwilkes/ux/ids"
+	"github.com/richardwilkes/ux/keys"
+)
+
+var (
+	// CloseAction closes the window with the keyboard focus.
+	CloseAction action.Action = &stdAction{
+		id:        ids.CloseItemID,
+		title:     func() string { return i18n.Text("Close") },
+		key:       keys.W,
+		mod:       keys.OSMenuCmdModifier(),
+		validator: CloseKeyWindowValidator,
+		handler:   CloseKeyWindowHandler,
+	}
+	// QuitAction asks the application to quit.
+	QuitAction action.Action = &stdAction{
+		id:      ids.QuitItemID,
+		title:   quitTitle,
+		key:     keys.Q,
+		mod:     keys.OSMenuCmdModifier(),
+		handler: func(*Item) { ux.AttemptQuit() },
+	}
+	// PreferencesAction calls the preferences handler given to the most
+	// recently created standard menu that had one. It is disabled until then.
+	PreferencesAction action.Action = &stdAction{
+		id:        ids.PreferencesItemID,
+		title:     func() string { return i18n.Text("Preferences…") },
+		key:       keys.Comma,
+		mod:       keys.OSMenuCmdModifier(),
+		validator: func(*Item) bool { return preferencesHandler != nil },
+		handler: func(item *Item) {
+			if preferencesHandler != nil {
+				preferencesHandler(item)
+			}
+		},
+	}
+	// MinimizeAction minimizes the window with the keyboard focus.
+	MinimizeAction action.Action = &stdAction{
+		id:        ids.MinimizeItemID,
+		title:     func() string { return i18n.Text("Minimize") },
+		key:       keys.M,
+		mod:       keys.OSMenuCmdModifier(),
+		validator: MinimizeValidator,
+		handler:   MinimizeHandler,
+	}
+	// ZoomAction zooms the window with the keyboard focus.
+	ZoomAction action.Action = &stdAction{
+		id:        ids.ZoomItemID,
+		title:     func() string { return i18n.Text("Zoom") },
+		key:       keys.Z,
+		mod:       keys.ShiftModifier | keys.OSMenuCmdModifier(),
+		validator: ZoomValidator,
+		handler:   ZoomHandler,
+	}
+	// HideAction hides the application. Only registered on macOS.
+	HideAction action.Action = &stdAction{
+		id:      ids.HideItemID,
+		title:   func() string { return fmt.Sprintf(i18n.Text("Hide %s"), cmdline.AppName) },
+		key:     keys.H,
+		mod:     keys.OSMenuCmdModifier(),
+		handler: func(*Item) { ux.HideApp() },
+	}
+	// HideOthersAction hides the other applications. Only registered on
+	// macOS.
+	HideOthersAction action.Action = &stdAction{
+		id:      ids.HideOthersItemID,
+		title:   func() string { return i18n.Text("Hide Others") },
+		key:     keys.H,
+		mod:     keys.OptionModifier | keys.OSMenuCmdModifier(),
+		handler: func(*Item) { ux.HideOtherApps() },
+	}
+	preferencesHandler ItemHandler
+)
+
+// stdAction adapts the validator and handler of a standard menu item to
+// action.Action, so that its hot key takes part in the key bindings like any
+// other registered action.
+type stdAction struct {
+	id        int
+	title     func() string
+	key       *keys.Key
+	mod       keys.Modifiers
+	validator ItemValidator
+	handler   ItemHandler
+}
+
+func init() {
+	action.Register(CloseAction, QuitAction, PreferencesAction, MinimizeAction, ZoomAction)
+	if runtime.GOOS == toolbox.MacOS {
+		action.Register(HideAction, HideOthersAction)
+	}
+}
+
+// ID implements action.Action.
+func (a *stdAction) ID() int {
+	return a.id
+}
+
+// Title implements action.Action.
+func (a *stdAction) Title() string {
+	return a.title()
+}
+
+// HotKey implements action.Action.
+func (a *stdAction) HotKey() *keys.Key {
+	return a.key
+}
+
+// HotKeyModifiers implements action.Action.
+func (a *stdAction) HotKeyModifiers() keys.Modifiers {
+	return a.mod
+}
+
+// Enabled implements action.Action.
+func (a *stdAction) Enabled(source interface{}) bool {
+	return a.validator == nil || a.validator(a.item(source))
+}
+
+// Execute implements action.Action.
+func (a *stdAction) Execute(source interface{}) {
+	a.handler(a.item(source))
+}
+
+// item returns the menu item to pass to the validator and handler. When the
+// action was triggered by something other than a menu item, such as a key
+// binding, the matching item from a menu bar is used, if there is one.
+func (a *stdAction) item(source interface{}) *Item {
+	if item, ok := source.(*Item); ok {
+		return item
+	}
+	for _, bar := range osAllMenuBars() {
+		if item := bar.MenuItem(a.id); item != nil {
+			return item
+		}
+	}
+	return nil
+}
+
+func quitTitle() string {
+	if runtime.GOOS == toolbox.MacOS {
+		return i18n.Text("Quit")
+	}
+	return i18n.Text("Exit")
+}
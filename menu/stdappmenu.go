@@ -18,7 +18,6 @@ import (
 	"github.com/richardwilkes/toolbox/i18n"
 	"github.com/richardwilkes/ux"
 	"github.com/richardwilkes/ux/ids"
-	"github.com/richardwilkes/ux/keys"
 )
 
 // NewAppMenu creates a standard 'App' menu. Really only intended for macOS,
@@ -28,14 +27,15 @@ func NewAppMenu(aboutHandler, prefsHandler ItemHandler, updater Updater) *Menu {
 	menu.InsertItem(-1, ids.AboutItemID, fmt.Sprintf(i18n.Text("About %s"), cmdline.AppName), nil, 0, func(*Item) bool { return aboutHandler != nil }, aboutHandler)
 	if prefsHandler != nil {
 		menu.InsertSeparator(-1)
-		menu.InsertItem(-1, ids.PreferencesItemID, i18n.Text("Preferences…"), keys.Comma, keys.OSMenuCmdModifier(), nil, prefsHandler)
+		preferencesHandler = prefsHandler
+		menu.InsertActionItem(-1, PreferencesAction)
 	}
 	if runtime.GOOS == toolbox.MacOS {
 		menu.InsertSeparator(-1)
 		menu.InsertMenu(-1, ids.ServicesMenuID, i18n.Text("Services"), nil)
 		menu.InsertSeparator(-1)
-		menu.InsertItem(-1, ids.HideItemID, fmt.Sprintf(i18n.Text("Hide %s"), cmdline.AppName), keys.H, keys.OSMenuCmdModifier(), nil, func(*Item) { ux.HideApp() })
-		menu.InsertItem(-1, ids.HideOthersItemID, i18n.Text("Hide Others"), keys.H, keys.OptionModifier|keys.OSMenuCmdModifier(), nil, func(*Item) { ux.HideOtherApps() })
+		menu.InsertActionItem(-1, HideAction)
+		menu.InsertActionItem(-1, HideOthersAction)
 		menu.InsertItem(-1, ids.ShowAllItemID, i18n.Text("Show All"), nil, 0, nil, func(*Item) { ux.ShowAllApps() })
 	}
 	menu.InsertSeparator(-1)
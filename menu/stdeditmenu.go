@@ -15,8 +15,6 @@ import (
 	"github.com/richardwilkes/toolbox"
 	"github.com/richardwilkes/toolbox/i18n"
 	"github.com/richardwilkes/ux/action"
-	"github.com/richardwilkes/ux/ids"
-	"github.com/richardwilkes/ux/keys"
 )
 
 // NewEditMenu creates a standard 'Edit' menu.
@@ -29,7 +27,27 @@ func NewEditMenu(prefsHandler ItemHandler, updater Updater) *Menu {
 	menu.InsertActionItem(-1, action.SelectAll)
 	if runtime.GOOS != toolbox.MacOS && prefsHandler != nil {
 		menu.InsertSeparator(-1)
-		menu.InsertItem(-1, ids.PreferencesItemID, i18n.Text("Preferences…"), keys.Comma, keys.OSMenuCmdModifier(), nil, prefsHandler)
+		preferencesHandler = prefsHandler
+		menu.InsertActionItem(-1, PreferencesAction)
+	}
+	return menu
+}
+
+// NewEditContextMenu creates a context menu containing the standard editing
+// commands that can currently be performed. Returns nil if none can be.
+func NewEditContextMenu() *Menu {
+	menu := New("", nil)
+	menu.InsertActionItemForContextMenu(-1, action.Cut)
+	menu.InsertActionItemForContextMenu(-1, action.Copy)
+	menu.InsertActionItemForContextMenu(-1, action.Paste)
+	menu.InsertActionItemForContextMenu(-1, action.Delete)
+	if action.SelectAll.Enabled(nil) {
+		menu.InsertSeparatorIfNeeded(-1)
+		menu.InsertActionItemForContextMenu(-1, action.SelectAll)
+	}
+	if menu.Count() == 0 {
+		menu.Dispose()
+		return nil
 	}
 	return menu
 }
@@ -15,8 +15,6 @@ import (
 	"github.com/richardwilkes/toolbox"
 	"github.com/richardwilkes/toolbox/i18n"
 	"github.com/richardwilkes/ux"
-	"github.com/richardwilkes/ux/ids"
-	"github.com/richardwilkes/ux/keys"
 )
 
 // NewFileMenu creates a standard 'File' menu.
@@ -33,7 +31,7 @@ func NewFileMenu(updater Updater) *Menu {
 // InsertCloseKeyWindowItem creates the standard "Close" menu item that will
 // close the current key window when chosen.
 func InsertCloseKeyWindowItem(menu *Menu, atIndex int) {
-	menu.InsertItem(atIndex, ids.CloseItemID, i18n.Text("Close"), keys.W, keys.OSMenuCmdModifier(), CloseKeyWindowValidator, CloseKeyWindowHandler)
+	menu.InsertActionItem(atIndex, CloseAction)
 }
 
 // CloseKeyWindowValidator provides the standard validation function for the
@@ -54,11 +52,5 @@ func CloseKeyWindowHandler(item *Item) {
 // InsertQuitItem creates the standard "Quit"/"Exit" menu item that will
 // issue the Quit command when chosen.
 func InsertQuitItem(menu *Menu, atIndex int) {
-	var title string
-	if runtime.GOOS == toolbox.MacOS {
-		title = i18n.Text("Quit")
-	} else {
-		title = i18n.Text("Exit")
-	}
-	menu.InsertItem(atIndex, ids.QuitItemID, title, keys.Q, keys.OSMenuCmdModifier(), nil, func(*Item) { ux.AttemptQuit() })
+	menu.InsertActionItem(atIndex, QuitAction)
 }
@@ -13,7 +13,6 @@ import (
 	"github.com/richardwilkes/toolbox/i18n"
 	"github.com/richardwilkes/ux"
 	"github.com/richardwilkes/ux/ids"
-	"github.com/richardwilkes/ux/keys"
 )
 
 // NewWindowMenu creates a standard 'Window' menu.
@@ -29,7 +28,7 @@ func NewWindowMenu(updater Updater) *Menu {
 // InsertMinimizeItem creates the standard "Minimize" menu item that will
 // issue the Minimize command to the current key window when chosen.
 func InsertMinimizeItem(menu *Menu, atIndex int) {
-	menu.InsertItem(atIndex, ids.MinimizeItemID, i18n.Text("Minimize"), keys.M, keys.OSMenuCmdModifier(), MinimizeValidator, MinimizeHandler)
+	menu.InsertActionItem(atIndex, MinimizeAction)
 }
 
 // MinimizeValidator provides the standard validation function for the
@@ -50,7 +49,7 @@ func MinimizeHandler(item *Item) {
 // InsertZoomItem creates the standard "Zoom" menu item that will issue the
 // Zoom command to the current key window when chosen.
 func InsertZoomItem(menu *Menu, atIndex int) {
-	menu.InsertItem(atIndex, ids.ZoomItemID, i18n.Text("Zoom"), keys.Z, keys.ShiftModifier|keys.OSMenuCmdModifier(), ZoomValidator, ZoomHandler)
+	menu.InsertActionItem(atIndex, ZoomAction)
 }
 
 // ZoomValidator provides the standard validation function for the "Zoom" menu
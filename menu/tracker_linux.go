@@ -0,0 +1,519 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package menu
+
+import (
+	"math"
+	"time"
+	"unicode"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ux"
+	"github.com/richardwilkes/ux/draw"
+	"github.com/richardwilkes/ux/keys"
+	"github.com/richardwilkes/ux/widget/checkbox/state"
+)
+
+const (
+	menuVMargin     = 4
+	itemHMargin     = 8
+	itemVMargin     = 3
+	hotKeyGap       = 24
+	separatorHeight = 7
+	// releaseDelay is how long after menus are opened that a mouse release is
+	// ignored, so that releasing the press that opened them doesn't also
+	// choose an item.
+	releaseDelay = 250 * time.Millisecond
+)
+
+var trackers = make(map[*ux.Window]*tracker)
+
+// tracker is installed as a window's overlay while its menus are open. It
+// draws the open menus and handles all mouse and keyboard interaction with
+// them.
+type tracker struct {
+	ux.Panel
+	wnd      *ux.Window
+	bar      *barPanel // nil for a popup menu
+	stack    []*dropDown
+	openedAt time.Time
+	barIndex int
+	keyboard bool // true once the keyboard has been used, to show mnemonics
+}
+
+// dropDown holds the layout and state of an open menu.
+type dropDown struct {
+	menu      *menuData
+	items     []*itemData
+	enabled   []bool
+	mnemonics []mnemonic
+	rows      []geom.Rect // Relative to the frame
+	frame     geom.Rect
+	markWidth float64
+	current   int
+}
+
+func newTracker(wnd *ux.Window, bar *barPanel) *tracker {
+	t := &tracker{
+		wnd:      wnd,
+		bar:      bar,
+		openedAt: time.Now(),
+		barIndex: -1,
+	}
+	t.InitTypeAndID(t)
+	t.DrawCallback = t.draw
+	t.MouseDownCallback = t.mouseDown
+	t.MouseDragCallback = t.mouseDrag
+	t.MouseUpCallback = t.mouseUp
+	t.MouseEnterCallback = t.mouseMove
+	t.MouseMoveCallback = t.mouseMove
+	t.KeyDownCallback = t.keyDown
+	if bar != nil {
+		bar.menu.update()
+	}
+	trackers[wnd] = t
+	wnd.SetOverlay(t.AsPanel())
+	t.AddWindowExitHook(t.closed)
+	return t
+}
+
+// active returns true if the tracker is still the one in use for its window.
+func (t *tracker) active() bool {
+	return trackers[t.wnd] == t
+}
+
+func (t *tracker) close() {
+	if t.active() {
+		t.wnd.SetOverlay(nil)
+	}
+}
+
+// closed is called once the tracker has been removed from its window, for
+// whatever reason.
+func (t *tracker) closed() {
+	if t.active() {
+		delete(trackers, t.wnd)
+	}
+	stack := t.stack
+	t.stack = nil
+	for _, d := range stack {
+		if d.menu.disposed {
+			d.menu.dispose()
+		}
+	}
+	if t.bar != nil {
+		t.bar.MarkForRedraw()
+	}
+}
+
+// isShowing returns true if the menu is one of those currently open.
+func (t *tracker) isShowing(m *menuData) bool {
+	for _, d := range t.stack {
+		if d.menu == m {
+			return true
+		}
+	}
+	return false
+}
+
+// popup shows the menu such that the item at currentIndex is positioned over
+// the rect, or, if there is no such item, just below it.
+func (t *tracker) popup(m *menuData, where geom.Rect, currentIndex int) {
+	d := newDropDown(m, where.Width)
+	d.frame.X = where.X
+	if currentIndex >= 0 && currentIndex < len(d.items) && !d.items[currentIndex].separator {
+		row := d.rows[currentIndex]
+		d.frame.Y = where.Y + (where.Height-row.Height)/2 - row.Y
+		d.current = currentIndex
+	} else {
+		d.frame.Y = where.Bottom()
+	}
+	t.push(d)
+}
+
+// openBarMenu opens the menu at the index within the menu bar, replacing any
+// other open menus. If the keyboard was used, its first item is selected.
+func (t *tracker) openBarMenu(index int, keyboard bool) {
+	t.stack = nil
+	t.barIndex = index
+	if keyboard {
+		t.keyboard = true
+	}
+	if item := t.bar.menu.items[index]; item.subMenu != nil {
+		d := newDropDown(item.subMenu, 0)
+		r := t.bar.RectToRoot(t.bar.titleRects()[index])
+		d.frame.Point = geom.Point{X: r.X, Y: r.Bottom() + 1}
+		if keyboard {
+			d.move(1)
+		}
+		t.push(d)
+	}
+	t.bar.MarkForRedraw()
+	t.MarkForRedraw()
+}
+
+// openSubMenu opens the sub-menu of the current item of the menu at the
+// level, closing any menus deeper than it.
+func (t *tracker) openSubMenu(level int) {
+	t.stack = t.stack[:level+1]
+	parent := t.stack[level]
+	if parent.current < 0 {
+		return
+	}
+	item := parent.items[parent.current]
+	if item.subMenu == nil || !parent.enabled[parent.current] {
+		return
+	}
+	d := newDropDown(item.subMenu, 0)
+	d.frame.X = parent.frame.Right() - 1
+	if d.frame.Right() > t.FrameRect().Width {
+		d.frame.X = parent.frame.X - d.frame.Width + 1
+	}
+	d.frame.Y = parent.frame.Y + parent.rows[parent.current].Y - menuVMargin
+	t.push(d)
+}
+
+// push adds the menu to the set of those open, moving it as needed to keep it
+// within the window.
+func (t *tracker) push(d *dropDown) {
+	bounds := t.FrameRect()
+	if d.frame.Right() > bounds.Width {
+		d.frame.X = bounds.Width - d.frame.Width
+	}
+	if d.frame.Bottom() > bounds.Height {
+		d.frame.Y = bounds.Height - d.frame.Height
+	}
+	d.frame.X = math.Max(d.frame.X, 0)
+	d.frame.Y = math.Max(d.frame.Y, 0)
+	t.stack = append(t.stack, d)
+	t.MarkForRedraw()
+}
+
+// pop closes the innermost open menu.
+func (t *tracker) pop() {
+	t.stack = t.stack[:len(t.stack)-1]
+	t.MarkForRedraw()
+}
+
+// choose closes the menus and then invokes the item.
+func (t *tracker) choose(item *itemData) {
+	t.close()
+	if item.enabled() {
+		item.invoke()
+	}
+}
+
+// activate chooses the item at the index within the menu at the level, or
+// opens its sub-menu.
+func (t *tracker) activate(level, index int) {
+	d := t.stack[level]
+	if index < 0 || !d.enabled[index] {
+		return
+	}
+	if d.items[index].subMenu != nil {
+		d.current = index
+		t.openSubMenu(level)
+		if len(t.stack) > level+1 {
+			t.stack[level+1].move(1)
+		}
+		t.MarkForRedraw()
+		return
+	}
+	t.choose(d.items[index])
+}
+
+func (t *tracker) draw(gc draw.Context, dirty geom.Rect, inLiveResize bool) {
+	for _, d := range t.stack {
+		d.draw(gc, t.keyboard)
+	}
+}
+
+func (t *tracker) mouseDown(where geom.Point, button, clickCount int, mod keys.Modifiers) bool {
+	for _, d := range t.stack {
+		if d.frame.ContainsPoint(where) {
+			t.track(where)
+			return true
+		}
+	}
+	if t.bar != nil {
+		if index := t.bar.titleAt(t.bar.PointFromRoot(where)); index >= 0 {
+			if index == t.barIndex {
+				t.close()
+			} else {
+				t.openBarMenu(index, false)
+			}
+			return true
+		}
+	}
+	t.close()
+	return true
+}
+
+func (t *tracker) mouseDrag(where geom.Point, button int, mod keys.Modifiers) {
+	if t.active() {
+		t.track(where)
+	}
+}
+
+func (t *tracker) mouseMove(where geom.Point, mod keys.Modifiers) {
+	if t.active() {
+		t.track(where)
+	}
+}
+
+func (t *tracker) mouseUp(where geom.Point, button int, mod keys.Modifiers) {
+	if !t.active() || time.Since(t.openedAt) < releaseDelay {
+		return
+	}
+	for i := len(t.stack) - 1; i >= 0; i-- {
+		d := t.stack[i]
+		if d.frame.ContainsPoint(where) {
+			if index := d.rowAt(where); index >= 0 && d.enabled[index] && d.items[index].subMenu == nil {
+				t.choose(d.items[index])
+			}
+			return
+		}
+	}
+}
+
+// track updates the open menus to reflect the mouse location.
+func (t *tracker) track(where geom.Point) {
+	for level := len(t.stack) - 1; level >= 0; level-- {
+		d := t.stack[level]
+		if !d.frame.ContainsPoint(where) {
+			continue
+		}
+		index := d.rowAt(where)
+		if index >= 0 && !d.enabled[index] {
+			index = -1
+		}
+		if index != d.current {
+			d.current = index
+			t.openSubMenu(level)
+			t.MarkForRedraw()
+		}
+		return
+	}
+	if t.bar != nil {
+		if index := t.bar.titleAt(t.bar.PointFromRoot(where)); index >= 0 {
+			if index != t.barIndex {
+				t.openBarMenu(index, false)
+			}
+			return
+		}
+	}
+	if len(t.stack) != 0 {
+		if d := t.stack[len(t.stack)-1]; d.current != -1 {
+			d.current = -1
+			t.MarkForRedraw()
+		}
+	}
+}
+
+func (t *tracker) keyDown(keyCode int, ch rune, mod keys.Modifiers, repeat bool) bool {
+	if len(t.stack) == 0 {
+		t.close()
+		return true
+	}
+	t.keyboard = true
+	t.MarkForRedraw()
+	if t.bar != nil {
+		t.bar.MarkForRedraw()
+	}
+	level := len(t.stack) - 1
+	d := t.stack[level]
+	switch keyCode {
+	case keys.Escape.Code:
+		if level > 0 {
+			t.pop()
+		} else {
+			t.close()
+		}
+	case keys.Up.Code:
+		d.move(-1)
+	case keys.Down.Code:
+		d.move(1)
+	case keys.Home.Code:
+		d.current = -1
+		d.move(1)
+	case keys.End.Code:
+		d.current = len(d.items)
+		d.move(-1)
+	case keys.Right.Code:
+		if d.current >= 0 && d.items[d.current].subMenu != nil {
+			t.activate(level, d.current)
+		} else if t.bar != nil {
+			t.openBarMenu((t.barIndex+1)%len(t.bar.menu.items), true)
+		}
+	case keys.Left.Code:
+		if level > 0 {
+			t.pop()
+		} else if t.bar != nil {
+			count := len(t.bar.menu.items)
+			t.openBarMenu((t.barIndex+count-1)%count, true)
+		}
+	case keys.Return.Code, keys.NumpadEnter.Code, keys.Space.Code:
+		t.activate(level, d.current)
+	default:
+		if ch != 0 && mod&(keys.ControlModifier|keys.CommandModifier) == 0 {
+			ch = unicode.ToLower(ch)
+			for i, m := range d.mnemonics {
+				if m.ch == ch && d.enabled[i] {
+					t.activate(level, i)
+					break
+				}
+			}
+		}
+	}
+	return true
+}
+
+func newDropDown(m *menuData, minWidth float64) *dropDown {
+	m.update()
+	d := &dropDown{
+		menu:    m,
+		items:   make([]*itemData, len(m.items)),
+		enabled: make([]bool, len(m.items)),
+		rows:    make([]geom.Rect, len(m.items)),
+		current: -1,
+	}
+	copy(d.items, m.items)
+	titles := make([]string, len(d.items))
+	for i, item := range d.items {
+		if !item.separator {
+			titles[i] = item.title
+			d.enabled[i] = item.enabled()
+		}
+	}
+	d.mnemonics = mnemonicsFor(titles)
+	d.markWidth = math.Ceil(draw.MenuFont.Height())
+	var titleWidth, hotKeyWidth float64
+	for i, item := range d.items {
+		if !item.separator {
+			titleWidth = math.Max(titleWidth, draw.MenuFont.Width(d.mnemonics[i].text))
+			if text := item.hotKeyText(); text != "" {
+				hotKeyWidth = math.Max(hotKeyWidth, draw.MenuCmdKeyFont.Width(text))
+			}
+		}
+	}
+	width := itemHMargin*2 + d.markWidth*2 + titleWidth
+	if hotKeyWidth > 0 {
+		width += hotKeyGap + hotKeyWidth
+	}
+	width = math.Ceil(math.Max(width, minWidth))
+	y := float64(menuVMargin)
+	for i, item := range d.items {
+		height := d.markWidth + itemVMargin*2
+		if item.separator {
+			height = separatorHeight
+		}
+		d.rows[i] = geom.Rect{Point: geom.Point{Y: y}, Size: geom.Size{Width: width, Height: height}}
+		y += height
+	}
+	d.frame.Size = geom.Size{Width: width, Height: y + menuVMargin}
+	return d
+}
+
+// rowAt returns the index of the item at the location, or -1.
+func (d *dropDown) rowAt(where geom.Point) int {
+	where.Subtract(d.frame.Point)
+	for i, row := range d.rows {
+		if !d.items[i].separator && row.ContainsPoint(where) {
+			return i
+		}
+	}
+	return -1
+}
+
+// move the selection by delta, skipping items that can't be chosen.
+func (d *dropDown) move(delta int) {
+	count := len(d.items)
+	i := d.current
+	if i < 0 && delta < 0 {
+		i = count
+	}
+	for range d.items {
+		i = (i + delta + count) % count
+		if d.enabled[i] {
+			d.current = i
+			return
+		}
+	}
+}
+
+func (d *dropDown) draw(gc draw.Context, showMnemonics bool) {
+	gc.Rect(d.frame)
+	gc.Fill(draw.ControlBackgroundColor)
+	edge := d.frame
+	edge.InsetUniform(0.5)
+	gc.Rect(edge)
+	gc.Stroke(draw.ControlEdgeAdjColor)
+	font := draw.MenuFont
+	keyFont := draw.MenuCmdKeyFont
+	for i, item := range d.items {
+		row := d.rows[i]
+		row.Point.Add(d.frame.Point)
+		if item.separator {
+			gc.Rect(geom.Rect{
+				Point: geom.Point{X: row.X + itemHMargin, Y: row.Y + math.Floor(row.Height/2)},
+				Size:  geom.Size{Width: row.Width - itemHMargin*2, Height: 1},
+			})
+			gc.Fill(draw.SeparatorColor)
+			continue
+		}
+		gc.Save()
+		ink := draw.LabelColor
+		if i == d.current {
+			gc.Rect(row)
+			gc.Fill(draw.SelectedContentBackgroundColor)
+			ink = draw.SelectedMenuItemTextColor
+		}
+		if !d.enabled[i] {
+			gc.SetOpacity(0.33)
+		}
+		mark := geom.Rect{
+			Point: geom.Point{X: row.X + itemHMargin, Y: row.Y + (row.Height-d.markWidth)/2},
+			Size:  geom.Size{Width: d.markWidth, Height: d.markWidth},
+		}
+		drawCheckMark(gc, mark, item.checkState, ink)
+		drawMnemonicText(gc, mark.Right(), row.Y+(row.Height-font.Height())/2, font, ink, d.mnemonics[i], showMnemonics)
+		right := row.Right() - itemHMargin - d.markWidth
+		if text := item.hotKeyText(); text != "" {
+			gc.DrawString(right-keyFont.Width(text), row.Y+(row.Height-keyFont.Height())/2, keyFont, ink, text)
+		}
+		if item.subMenu != nil {
+			size := d.markWidth / 2
+			x := right + (d.markWidth-size/2)/2
+			y := row.Y + (row.Height-size)/2
+			gc.MoveTo(x, y)
+			gc.LineTo(x+size/2, y+size/2)
+			gc.LineTo(x, y+size)
+			gc.ClosePath()
+			gc.Fill(ink)
+		}
+		gc.Restore()
+	}
+}
+
+func drawCheckMark(gc draw.Context, rect geom.Rect, s state.State, ink draw.Ink) {
+	switch s {
+	case state.Mixed:
+		gc.SetStrokeWidth(2)
+		gc.MoveTo(rect.X+rect.Width*0.25, rect.Y+rect.Height*0.5)
+		gc.LineTo(rect.X+rect.Width*0.7, rect.Y+rect.Height*0.5)
+		gc.Stroke(ink)
+	case state.On:
+		gc.SetStrokeWidth(2)
+		gc.MoveTo(rect.X+rect.Width*0.25, rect.Y+rect.Height*0.55)
+		gc.LineTo(rect.X+rect.Width*0.45, rect.Y+rect.Height*0.7)
+		gc.LineTo(rect.X+rect.Width*0.75, rect.Y+rect.Height*0.3)
+		gc.Stroke(ink)
+	}
+}
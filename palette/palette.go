@@ -0,0 +1,270 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+// Package palette provides a command palette, which allows the user to search
+// for and perform any of the registered actions from the keyboard.
+package palette
+
+import (
+	"math"
+
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ux"
+	"github.com/richardwilkes/ux/action"
+	"github.com/richardwilkes/ux/border"
+	"github.com/richardwilkes/ux/draw"
+	"github.com/richardwilkes/ux/ids"
+	"github.com/richardwilkes/ux/keybinding"
+	"github.com/richardwilkes/ux/keys"
+	"github.com/richardwilkes/ux/layout/align"
+	"github.com/richardwilkes/ux/layout/flex"
+	"github.com/richardwilkes/ux/widget/list"
+	"github.com/richardwilkes/ux/widget/scrollarea"
+	"github.com/richardwilkes/ux/widget/scrollarea/behavior"
+	"github.com/richardwilkes/ux/widget/textfield"
+)
+
+const (
+	maxWidth    = 480
+	margin      = 10
+	topMargin   = 40
+	visibleRows = 10
+)
+
+// ShowAction displays the command palette in the window that has the keyboard
+// focus. It is added to the action registry so that its key binding may be
+// changed, but is never listed in the palette itself.
+var ShowAction action.Action = &showAction{}
+
+func init() {
+	action.Register(ShowAction)
+}
+
+type palette struct {
+	ux.Panel
+	wnd      *ux.Window
+	focus    *ux.Panel
+	actions  []action.Action
+	matches  []*action.Match
+	box      *ux.Panel
+	field    *textfield.TextField
+	lst      *list.List
+	scroller *scrollarea.ScrollArea
+	factory  *cellFactory
+}
+
+// Show displays the command palette over the content of the window. The
+// palette lists the registered actions that are enabled for the window's
+// current focus, narrowing them down as the user types. Does nothing if the
+// window already has an overlay, such as an open menu.
+func Show(wnd *ux.Window) {
+	if wnd == nil || !wnd.IsValid() || wnd.Overlay() != nil {
+		return
+	}
+	p := &palette{
+		wnd:     wnd,
+		focus:   wnd.Focus(),
+		factory: &cellFactory{},
+	}
+	for _, one := range action.AllEnabled(p) {
+		if one != ShowAction {
+			p.actions = append(p.actions, one)
+		}
+	}
+	p.InitTypeAndID(p)
+	p.SetLayout(p)
+	p.MouseDownCallback = p.mouseDown
+	p.KeyDownCallback = p.keyDown
+
+	p.box = ux.NewPanel()
+	p.box.SetBorder(border.NewCompound(border.NewLine(draw.UnemphasizedSelectedContentBackgroundColor, 0, geom.NewUniformInsets(1), false), border.NewEmpty(geom.NewUniformInsets(4))))
+	p.box.DrawCallback = func(gc draw.Context, dirty geom.Rect, inLiveResize bool) {
+		gc.Rect(p.box.ContentRect(true))
+		gc.Fill(draw.ControlBackgroundColor)
+	}
+	flex.New().VSpacing(4).Apply(p.box)
+	p.AddChild(p.box)
+
+	p.field = textfield.New()
+	p.field.ModifiedCallback = p.refilter
+	flex.NewData().HAlign(align.Fill).HGrab(true).Apply(p.field)
+	p.box.AddChild(p.field.AsPanel())
+
+	p.lst = list.New().SetFactory(p.factory)
+	p.lst.ContextMenuCallback = nil
+	p.lst.DoubleClickCallback = p.performSelection
+	p.lst.MouseDownCallback = func(where geom.Point, button, clickCount int, mod keys.Modifiers) bool {
+		p.lst.DefaultMouseDown(where, button, clickCount, mod)
+		p.field.RequestFocus()
+		return true
+	}
+	p.scroller = scrollarea.New().SetContent(p.lst.AsPanel(), behavior.Fill)
+	flex.NewData().HAlign(align.Fill).VAlign(align.Fill).HGrab(true).VGrab(true).SizeHint(geom.Size{Height: visibleRows * p.factory.CellHeight()}).Apply(p.scroller)
+	p.box.AddChild(p.scroller.AsPanel())
+
+	p.refilter()
+	p.AddWindowExitHook(p.closed)
+	wnd.SetOverlay(p.AsPanel())
+	p.field.RequestFocus()
+}
+
+// Sizes implements layout.Layout.
+func (p *palette) Sizes(hint geom.Size) (min, pref, max geom.Size) {
+	return p.box.Sizes(hint)
+}
+
+// Layout implements layout.Layout. The box holding the field and list is
+// centered horizontally near the top of the window.
+func (p *palette) Layout() {
+	rect := p.ContentRect(false)
+	width := math.Max(math.Min(maxWidth, rect.Width-2*margin), 0)
+	_, pref, _ := p.box.Sizes(geom.Size{Width: width})
+	top := math.Min(topMargin, math.Max(rect.Height-pref.Height, 0)/2)
+	height := math.Max(math.Min(pref.Height, rect.Height-(top+margin)), 0)
+	p.box.SetFrameRect(geom.Rect{
+		Point: geom.Point{X: rect.X + math.Floor((rect.Width-width)/2), Y: rect.Y + top},
+		Size:  geom.Size{Width: width, Height: height},
+	})
+}
+
+func (p *palette) refilter() {
+	for i := len(p.matches) - 1; i >= 0; i-- {
+		p.lst.Remove(i)
+	}
+	p.matches = action.Search(p.field.Text(), p.actions)
+	for _, one := range p.matches {
+		p.lst.Append(one)
+	}
+	p.lst.Select(false, 0)
+	p.scroller.SetScrolledPosition(false, 0)
+}
+
+func (p *palette) moveSelection(delta int) {
+	if len(p.matches) == 0 {
+		return
+	}
+	index := p.lst.Selection.FirstSet()
+	switch {
+	case index < 0 && delta < 0:
+		index = len(p.matches) - 1
+	case index < 0:
+		index = 0
+	default:
+		index += delta
+	}
+	if index < 0 {
+		index = 0
+	} else if index >= len(p.matches) {
+		index = len(p.matches) - 1
+	}
+	p.lst.Select(false, index)
+	height := p.factory.CellHeight()
+	p.lst.ScrollRectIntoView(geom.Rect{Point: geom.Point{Y: p.lst.ContentRect(false).Y + float64(index)*height}, Size: geom.Size{Width: 1, Height: height}})
+}
+
+func (p *palette) performSelection() {
+	index := p.lst.Selection.FirstSet()
+	if index < 0 || index >= len(p.matches) {
+		return
+	}
+	a := p.matches[index].Action
+	p.close()
+	action.Perform(a, p)
+}
+
+func (p *palette) close() {
+	if p.wnd.Overlay() == p.AsPanel() {
+		p.wnd.SetOverlay(nil)
+	}
+}
+
+// closed is called once the palette has been removed from its window, for
+// whatever reason.
+func (p *palette) closed() {
+	p.wnd.SetFocus(p.focus)
+}
+
+func (p *palette) mouseDown(where geom.Point, button, clickCount int, mod keys.Modifiers) bool {
+	p.close()
+	return true
+}
+
+func (p *palette) keyDown(keyCode int, ch rune, mod keys.Modifiers, repeat bool) bool {
+	switch keyCode {
+	case keys.Escape.Code:
+		p.close()
+	case keys.Up.Code:
+		p.moveSelection(-1)
+	case keys.Down.Code:
+		p.moveSelection(1)
+	case keys.PageUp.Code:
+		p.moveSelection(-(visibleRows - 1))
+	case keys.PageDown.Code:
+		p.moveSelection(visibleRows - 1)
+	case keys.Return.Code, keys.NumpadEnter.Code:
+		p.performSelection()
+	default:
+		return false
+	}
+	return true
+}
+
+type cellFactory struct{}
+
+// CellHeight implements widget.CellFactory.
+func (f *cellFactory) CellHeight() float64 {
+	return action.CellHeight()
+}
+
+// CreateCell implements widget.CellFactory.
+func (f *cellFactory) CreateCell(owner *ux.Panel, element interface{}, index int, selected, focused bool) *ux.Panel {
+	m, ok := element.(*action.Match)
+	if !ok {
+		return ux.NewPanel()
+	}
+	var hotKey string
+	if bindings := keybinding.Bindings(m.Action); len(bindings) != 0 {
+		hotKey = bindings[0].SymbolString()
+	}
+	return action.NewCell(m.Action, hotKey, draw.SecondaryLabelColor, selected)
+}
+
+type showAction struct{}
+
+// ID implements action.Action.
+func (a *showAction) ID() int {
+	return ids.CommandPaletteItemID
+}
+
+// Title implements action.Action.
+func (a *showAction) Title() string {
+	return i18n.Text("Command Palette…")
+}
+
+// HotKey implements action.Action.
+func (a *showAction) HotKey() *keys.Key {
+	return keys.P
+}
+
+// HotKeyModifiers implements action.Action.
+func (a *showAction) HotKeyModifiers() keys.Modifiers {
+	return keys.OSMenuCmdModifier() | keys.ShiftModifier
+}
+
+// Enabled implements action.Action.
+func (a *showAction) Enabled(source interface{}) bool {
+	wnd := ux.WindowWithFocus()
+	return wnd != nil && wnd.Overlay() == nil
+}
+
+// Execute implements action.Action.
+func (a *showAction) Execute(source interface{}) {
+	Show(ux.WindowWithFocus())
+}
@@ -40,6 +40,10 @@ type Panel struct {
 	layout                              layout.Layout
 	layoutData                          interface{}
 	children                            []*Panel
+	direction                           layout.Direction
+	transition                          *LayoutTransition
+	transitionState                     *layoutTransitionState
+	windowExitHooks                     []*windowExitHook
 	Tooltip                             *Panel
 	data                                map[string]interface{}
 	DrawCallback                        func(gc draw.Context, dirty geom.Rect, inLiveResize bool)
@@ -66,6 +70,7 @@ type Panel struct {
 	DropFinishedCallback                func(dragInfo *DragInfo)
 	CanPerformCmdCallback               func(source interface{}, id int) bool
 	PerformCmdCallback                  func(source interface{}, id int)
+	ContextMenuCallback                 func(where geom.Point) ContextMenu
 	FrameChangeCallback                 func()
 	FrameChangeInChildHierarchyCallback func(panel *Panel)
 	ScrollRectIntoViewCallback          func(rect geom.Rect) bool
@@ -73,6 +78,7 @@ type Panel struct {
 	NeedsLayout                         bool
 	focusable                           bool
 	disabled                            bool
+	hidden                              bool
 }
 
 // NewPanel creates a new panel.
@@ -149,20 +155,24 @@ func (p *Panel) IndexOfChild(child *Panel) int {
 // AddChild adds child to this panel, removing it from any previous parent it
 // may have had.
 func (p *Panel) AddChild(child *Panel) {
-	child.RemoveFromParent()
+	wnd := child.Window()
+	child.detachFromParent()
 	p.children = append(p.children, child)
 	child.parent = p
 	p.NeedsLayout = true
 	if child.ParentChangedCallback != nil {
 		child.ParentChangedCallback()
 	}
+	child.windowChanged(wnd)
+	p.childAdded(child)
 }
 
 // AddChildAtIndex adds child to this panel at the index, removing it from any
 // previous parent it may have had. Passing in a negative value for the index
 // will add it to the end.
 func (p *Panel) AddChildAtIndex(child *Panel, index int) {
-	child.RemoveFromParent()
+	wnd := child.Window()
+	child.detachFromParent()
 	if index < 0 || index >= len(p.children) {
 		p.children = append(p.children, child)
 	} else {
@@ -175,13 +185,18 @@ func (p *Panel) AddChildAtIndex(child *Panel, index int) {
 	if child.ParentChangedCallback != nil {
 		child.ParentChangedCallback()
 	}
+	child.windowChanged(wnd)
+	p.childAdded(child)
 }
 
 // RemoveAllChildren removes all child panels from this panel.
 func (p *Panel) RemoveAllChildren() {
+	wnd := p.Window()
 	children := p.children
 	for _, child := range children {
 		child.parent = nil
+		child.hidden = false
+		p.childRemovedDuringTransition(child)
 	}
 	p.children = nil
 	p.NeedsLayout = true
@@ -189,6 +204,7 @@ func (p *Panel) RemoveAllChildren() {
 		if child.ParentChangedCallback != nil {
 			child.ParentChangedCallback()
 		}
+		child.windowChanged(wnd)
 	}
 }
 
@@ -201,9 +217,20 @@ func (p *Panel) RemoveChild(child *Panel) {
 // RemoveChildAtIndex removes the child panel at 'index' from this panel.
 // If 'index' is out of range, nothing happens.
 func (p *Panel) RemoveChildAtIndex(index int) {
+	if index >= 0 && index < len(p.children) {
+		child := p.children[index]
+		wnd := child.Window()
+		p.removeChildAtIndex(index)
+		child.windowChanged(wnd)
+	}
+}
+
+func (p *Panel) removeChildAtIndex(index int) {
 	if index >= 0 && index < len(p.children) {
 		child := p.children[index]
 		child.parent = nil
+		child.hidden = false
+		p.childRemovedDuringTransition(child)
 		copy(p.children[index:], p.children[index+1:])
 		p.children[len(p.children)-1] = nil
 		p.children = p.children[:len(p.children)-1]
@@ -221,6 +248,14 @@ func (p *Panel) RemoveFromParent() {
 	}
 }
 
+// detachFromParent removes this panel from its parent, if any, without
+// notifying the window exit hooks, as it is about to be added elsewhere.
+func (p *Panel) detachFromParent() {
+	if p.parent != nil {
+		p.parent.removeChildAtIndex(p.parent.IndexOfChild(p))
+	}
+}
+
 // Parent returns the parent panel, if any.
 func (p *Panel) Parent() *Panel {
 	return p.parent
@@ -332,18 +367,36 @@ func (p *Panel) Layout() layout.Layout {
 	return p.layout
 }
 
-// SetLayout sets the Layout for this panel. May be nil.
+// SetLayout sets the Layout for this panel. May be nil. Replacing a layout
+// makes any children it had hidden visible again.
 func (p *Panel) SetLayout(lay layout.Layout) {
+	if p.layout != lay {
+		for _, child := range p.children {
+			child.hidden = false
+		}
+	}
 	p.layout = lay
 	p.NeedsLayout = true
 }
 
+// childAdded gives the layout a chance to hide the newly added child, for
+// example when it is a stack that isn't showing it.
+func (p *Panel) childAdded(child *Panel) {
+	if t, ok := p.layout.(interface{ ChildAdded(child layout.Layoutable) }); ok {
+		t.ChildAdded(child)
+	}
+}
+
 // ValidateLayout performs any layout that needs to be run by this panel or
 // its children.
 func (p *Panel) ValidateLayout() {
 	if p.NeedsLayout {
 		if p.layout != nil {
-			p.layout.Layout()
+			if p.layoutTransitionEnabled() {
+				p.layoutWithTransition()
+			} else {
+				p.layout.Layout()
+			}
 			p.MarkForRedraw()
 		}
 		p.NeedsLayout = false
@@ -353,6 +406,46 @@ func (p *Panel) ValidateLayout() {
 	}
 }
 
+// LayoutDirection returns the direction content flows in for this panel. If
+// this panel hasn't had a direction set, its parent's direction is used. If
+// no panel in the hierarchy has one set, the window's direction is used.
+func (p *Panel) LayoutDirection() layout.Direction {
+	panel := p
+	for {
+		if panel.direction != layout.InheritDirection {
+			return panel.direction
+		}
+		if panel.parent == nil {
+			break
+		}
+		panel = panel.parent
+	}
+	if root, ok := panel.Self().(*rootPanel); ok && root.window != nil {
+		return root.window.LayoutDirection()
+	}
+	return layout.DirectionOf(nil)
+}
+
+// SetLayoutDirection sets the direction content flows in for this panel and
+// any of its descendants that don't have their own direction set. Pass in
+// layout.InheritDirection to use the direction of the enclosing panel or
+// window.
+func (p *Panel) SetLayoutDirection(direction layout.Direction) *Panel {
+	if p.direction != direction {
+		p.direction = direction
+		p.markTreeForLayout()
+		p.MarkForRedraw()
+	}
+	return p
+}
+
+func (p *Panel) markTreeForLayout() {
+	p.NeedsLayout = true
+	for _, child := range p.children {
+		child.markTreeForLayout()
+	}
+}
+
 // LayoutData returns the layout data, if any, associated with this panel.
 func (p *Panel) LayoutData() interface{} {
 	return p.layoutData
@@ -420,10 +513,14 @@ func (p *Panel) Draw(gc draw.Context, dirty geom.Rect, inLiveResize bool) {
 				gc.Translate(child.frame.X, child.frame.Y)
 				adjusted.X -= child.frame.X
 				adjusted.Y -= child.frame.Y
+				if opacity := p.childOpacity(child); opacity < 1 {
+					gc.SetOpacity(opacity)
+				}
 				child.Draw(gc, adjusted, inLiveResize)
 				gc.Restore()
 			}
 		}
+		p.drawDeparting(gc, dirty, inLiveResize)
 		if p.border != nil {
 			gc.Save()
 			p.border.Draw(gc, p.ContentRect(true), inLiveResize)
@@ -464,6 +561,41 @@ func (p *Panel) SetFocusable(focusable bool) *Panel {
 	return p
 }
 
+// Hidden returns true if this panel has been hidden, for example by a stack
+// layout that isn't currently showing it.
+func (p *Panel) Hidden() bool {
+	return p.hidden
+}
+
+// SetHidden sets whether this panel is hidden. A hidden panel and its
+// descendants can't have the keyboard focus and are skipped when moving the
+// focus with the keyboard. If the focus is within the panel when it is
+// hidden, the focus is moved elsewhere. This does not affect the panel's
+// frame; that remains the responsibility of its parent's layout. The panel
+// is made visible again when it is removed from its parent or its parent's
+// layout is replaced.
+func (p *Panel) SetHidden(hidden bool) *Panel {
+	if p.hidden != hidden {
+		p.hidden = hidden
+		if hidden {
+			if wnd := p.Window(); wnd != nil {
+				wnd.moveFocusOutOf(p)
+			}
+		}
+	}
+	return p
+}
+
+// inHiddenTree returns true if this panel or one of its ancestors is hidden.
+func (p *Panel) inHiddenTree() bool {
+	for one := p; one != nil; one = one.parent {
+		if one.hidden {
+			return true
+		}
+	}
+	return false
+}
+
 // Focused returns true if this panel has the keyboard focus.
 func (p *Panel) Focused() bool {
 	if wnd := p.Window(); wnd != nil {
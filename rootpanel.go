@@ -19,6 +19,7 @@ type rootPanel struct {
 	window  *Window
 	menubar *Panel
 	content *Panel
+	overlay *Panel
 	tooltip *Panel
 }
 
@@ -33,7 +34,7 @@ func newRootPanel(wnd *Window) *rootPanel {
 	return p
 }
 
-func (p *rootPanel) setMenuBar(bar *Panel) { //nolint:unused
+func (p *rootPanel) setMenuBar(bar *Panel) {
 	if p.menubar != nil {
 		p.RemoveChild(p.menubar)
 	}
@@ -61,6 +62,31 @@ func (p *rootPanel) setContent(content *Panel) {
 	p.MarkForRedraw()
 }
 
+func (p *rootPanel) setOverlay(overlay *Panel) {
+	if p.overlay != nil {
+		p.RemoveChild(p.overlay)
+	}
+	p.overlay = overlay
+	if overlay != nil {
+		index := len(p.children)
+		if p.tooltip != nil {
+			index--
+		}
+		p.AddChildAtIndex(overlay, index)
+	}
+	p.NeedsLayout = true
+	p.MarkForRedraw()
+}
+
+// PanelAt returns the overlay, if present, so that it sees all mouse events
+// in the window. Otherwise, it behaves the same as Panel.PanelAt().
+func (p *rootPanel) PanelAt(pt geom.Point) *Panel {
+	if p.overlay != nil {
+		return p.overlay.PanelAt(pt)
+	}
+	return p.Panel.PanelAt(pt)
+}
+
 func (p *rootPanel) setTooltip(tip *Panel) {
 	if p.tooltip != nil {
 		p.tooltip.MarkForRedraw()
@@ -102,4 +128,7 @@ func (l *rootLayout) Layout() {
 		rect.Height -= size.Height
 	}
 	l.root.content.SetFrameRect(rect)
+	if l.root.overlay != nil {
+		l.root.overlay.SetFrameRect(geom.Rect{Size: l.root.frame.Size})
+	}
 }
@@ -0,0 +1,396 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package uidef
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ux/border"
+	"github.com/richardwilkes/ux/draw"
+	"github.com/richardwilkes/ux/layout/align"
+	"github.com/richardwilkes/ux/layout/side"
+	"github.com/richardwilkes/ux/widget/checkbox/state"
+	"gopkg.in/yaml.v3"
+)
+
+var namedFonts = map[string]**draw.Font{
+	"user":                  &draw.UserFont,
+	"userMonospaced":        &draw.UserMonospacedFont,
+	"system":                &draw.SystemFont,
+	"emphasizedSystem":      &draw.EmphasizedSystemFont,
+	"smallSystem":           &draw.SmallSystemFont,
+	"smallEmphasizedSystem": &draw.SmallEmphasizedSystemFont,
+	"views":                 &draw.ViewsFont,
+	"label":                 &draw.LabelFont,
+	"menu":                  &draw.MenuFont,
+	"menuCmdKey":            &draw.MenuCmdKeyFont,
+}
+
+func nodeError(node *yaml.Node, format string, args ...interface{}) error {
+	return errs.Newf("line %d, column %d: %s", node.Line, node.Column, fmt.Sprintf(format, args...))
+}
+
+func resolve(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	return node
+}
+
+// mapping holds the keys and values of a mapping node.
+type mapping struct {
+	keys   map[string]*yaml.Node
+	values map[string]*yaml.Node
+	order  []string
+}
+
+func newMapping(node *yaml.Node, what string) (*mapping, error) {
+	node = resolve(node)
+	if node.Kind != yaml.MappingNode {
+		return nil, nodeError(node, "expected a mapping for %s", what)
+	}
+	m := &mapping{
+		keys:   make(map[string]*yaml.Node),
+		values: make(map[string]*yaml.Node),
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := resolve(node.Content[i])
+		if key.Kind != yaml.ScalarNode {
+			return nil, nodeError(key, "expected a key name for %s", what)
+		}
+		if _, exists := m.keys[key.Value]; exists {
+			return nil, nodeError(key, "duplicate key %q for %s", key.Value, what)
+		}
+		m.keys[key.Value] = key
+		m.values[key.Value] = resolve(node.Content[i+1])
+		m.order = append(m.order, key.Value)
+	}
+	return m, nil
+}
+
+// check returns an error for the first key that isn't one of the allowed
+// keys.
+func (m *mapping) check(what string, allowed ...string) error {
+	for _, key := range m.order {
+		found := false
+		for _, one := range allowed {
+			if key == one {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nodeError(m.keys[key], "unknown key %q for %s; expected one of: %s", key, what, strings.Join(allowed, ", "))
+		}
+	}
+	return nil
+}
+
+func (m *mapping) require(node *yaml.Node, key, what string) (*yaml.Node, error) {
+	if value, ok := m.values[key]; ok {
+		return value, nil
+	}
+	return nil, nodeError(node, "missing key %q for %s", key, what)
+}
+
+func decodeScalar(node *yaml.Node, out interface{}, what string) error {
+	node = resolve(node)
+	if node.Kind != yaml.ScalarNode || node.Decode(out) != nil {
+		return nodeError(node, "expected %s", what)
+	}
+	return nil
+}
+
+func decodeBool(node *yaml.Node) (bool, error) {
+	var value bool
+	err := decodeScalar(node, &value, "a boolean")
+	return value, err
+}
+
+func decodeInt(node *yaml.Node) (int, error) {
+	var value int
+	err := decodeScalar(node, &value, "an integer")
+	return value, err
+}
+
+func decodeFloat64(node *yaml.Node) (float64, error) {
+	var value float64
+	err := decodeScalar(node, &value, "a number")
+	return value, err
+}
+
+func decodeString(node *yaml.Node) (string, error) {
+	var value string
+	err := decodeScalar(node, &value, "a string")
+	return value, err
+}
+
+// decodeEnum returns the index of the name the node holds.
+func decodeEnum(node *yaml.Node, what string, names ...string) (int, error) {
+	str, err := decodeString(node)
+	if err == nil {
+		for i, name := range names {
+			if str == name {
+				return i, nil
+			}
+		}
+		err = nodeError(node, "invalid %s %q; expected one of: %s", what, str, strings.Join(names, ", "))
+	}
+	return 0, err
+}
+
+func decodeAlignment(node *yaml.Node) (align.Alignment, error) {
+	i, err := decodeEnum(node, "alignment", "start", "middle", "end", "fill")
+	return align.Alignment(i), err
+}
+
+func decodeSide(node *yaml.Node) (side.Side, error) {
+	i, err := decodeEnum(node, "side", "top", "left", "bottom", "right")
+	return side.Side(i), err
+}
+
+func decodeState(node *yaml.Node) (state.State, error) {
+	i, err := decodeEnum(node, "state", "off", "on", "mixed")
+	return state.State(i), err
+}
+
+func decodeDuration(node *yaml.Node) (time.Duration, error) {
+	str, err := decodeString(node)
+	if err != nil {
+		return 0, err
+	}
+	var duration time.Duration
+	if duration, err = time.ParseDuration(str); err != nil {
+		return 0, nodeError(node, "invalid duration %q", str)
+	}
+	return duration, nil
+}
+
+func decodeInk(node *yaml.Node) (draw.Ink, error) {
+	str, err := decodeString(node)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(str) == "" {
+		return nil, nodeError(node, "expected a color")
+	}
+	return draw.ColorDecode(str), nil
+}
+
+// decodeFont accepts either the name of one of the standard fonts, such as
+// "system" or "label", or a mapping with family, size, bold and italic keys.
+func decodeFont(node *yaml.Node) (*draw.Font, error) {
+	node = resolve(node)
+	if node.Kind == yaml.ScalarNode {
+		if font, ok := namedFonts[node.Value]; ok {
+			return *font, nil
+		}
+		names := make([]string, 0, len(namedFonts))
+		for name := range namedFonts {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, nodeError(node, "unknown font %q; expected a mapping or one of: %s", node.Value, strings.Join(names, ", "))
+	}
+	m, err := newMapping(node, "font")
+	if err != nil {
+		return nil, err
+	}
+	if err = m.check("font", "family", "size", "bold", "italic"); err != nil {
+		return nil, err
+	}
+	desc := draw.SystemFont.Descriptor()
+	if value, ok := m.values["family"]; ok {
+		if desc.Family, err = decodeString(value); err != nil {
+			return nil, err
+		}
+	}
+	if value, ok := m.values["size"]; ok {
+		if desc.Size, err = decodeFloat64(value); err != nil {
+			return nil, err
+		}
+	}
+	if value, ok := m.values["bold"]; ok {
+		if desc.Bold, err = decodeBool(value); err != nil {
+			return nil, err
+		}
+	}
+	if value, ok := m.values["italic"]; ok {
+		if desc.Italic, err = decodeBool(value); err != nil {
+			return nil, err
+		}
+	}
+	return draw.NewFont(desc), nil
+}
+
+// decodeImage accepts either a URL or a mapping with url and scale keys.
+func decodeImage(node *yaml.Node) (*draw.Image, error) {
+	node = resolve(node)
+	urlNode := node
+	scale := 1.0
+	if node.Kind == yaml.MappingNode {
+		m, err := newMapping(node, "image")
+		if err != nil {
+			return nil, err
+		}
+		if err = m.check("image", "url", "scale"); err != nil {
+			return nil, err
+		}
+		if urlNode, err = m.require(node, "url", "image"); err != nil {
+			return nil, err
+		}
+		if value, ok := m.values["scale"]; ok {
+			if scale, err = decodeFloat64(value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	url, err := decodeString(urlNode)
+	if err != nil {
+		return nil, err
+	}
+	img, err := draw.NewImageFromURL(url, scale)
+	if err != nil {
+		return nil, errs.NewWithCause(nodeError(urlNode, "unable to load image %q", url).Error(), err)
+	}
+	return img, nil
+}
+
+// decodeInsets accepts either a single number to be used for all sides or a
+// mapping with top, left, bottom and right keys.
+func decodeInsets(node *yaml.Node) (geom.Insets, error) {
+	node = resolve(node)
+	if node.Kind == yaml.ScalarNode {
+		amount, err := decodeFloat64(node)
+		return geom.NewUniformInsets(amount), err
+	}
+	var insets geom.Insets
+	m, err := newMapping(node, "insets")
+	if err != nil {
+		return insets, err
+	}
+	if err = m.check("insets", "top", "left", "bottom", "right"); err != nil {
+		return insets, err
+	}
+	for key, value := range map[string]*float64{
+		"top":    &insets.Top,
+		"left":   &insets.Left,
+		"bottom": &insets.Bottom,
+		"right":  &insets.Right,
+	} {
+		if n, ok := m.values[key]; ok {
+			if *value, err = decodeFloat64(n); err != nil {
+				return insets, err
+			}
+		}
+	}
+	return insets, nil
+}
+
+// decodeSize accepts a mapping with width and height keys.
+func decodeSize(node *yaml.Node) (geom.Size, error) {
+	var size geom.Size
+	m, err := newMapping(node, "size")
+	if err != nil {
+		return size, err
+	}
+	if err = m.check("size", "width", "height"); err != nil {
+		return size, err
+	}
+	if value, ok := m.values["width"]; ok {
+		if size.Width, err = decodeFloat64(value); err != nil {
+			return size, err
+		}
+	}
+	if value, ok := m.values["height"]; ok {
+		if size.Height, err = decodeFloat64(value); err != nil {
+			return size, err
+		}
+	}
+	return size, nil
+}
+
+// decodeBorder accepts a mapping with a type key of "empty", "line" or
+// "compound" and the keys appropriate for that type.
+func decodeBorder(node *yaml.Node) (border.Border, error) {
+	m, err := newMapping(node, "border")
+	if err != nil {
+		return nil, err
+	}
+	typeNode, err := m.require(node, "type", "border")
+	if err != nil {
+		return nil, err
+	}
+	kind, err := decodeEnum(typeNode, "border type", "empty", "line", "compound")
+	if err != nil {
+		return nil, err
+	}
+	var insets geom.Insets
+	if value, ok := m.values["insets"]; ok {
+		if insets, err = decodeInsets(value); err != nil {
+			return nil, err
+		}
+	}
+	switch kind {
+	case 0:
+		if err = m.check("empty border", "type", "insets"); err != nil {
+			return nil, err
+		}
+		return border.NewEmpty(insets), nil
+	case 1:
+		if err = m.check("line border", "type", "insets", "ink", "cornerRadius", "noInset"); err != nil {
+			return nil, err
+		}
+		var ink draw.Ink = draw.ControlEdgeAdjColor
+		if value, ok := m.values["ink"]; ok {
+			if ink, err = decodeInk(value); err != nil {
+				return nil, err
+			}
+		}
+		var cornerRadius float64
+		if value, ok := m.values["cornerRadius"]; ok {
+			if cornerRadius, err = decodeFloat64(value); err != nil {
+				return nil, err
+			}
+		}
+		var noInset bool
+		if value, ok := m.values["noInset"]; ok {
+			if noInset, err = decodeBool(value); err != nil {
+				return nil, err
+			}
+		}
+		return border.NewLine(ink, cornerRadius, insets, noInset), nil
+	default:
+		if err = m.check("compound border", "type", "borders"); err != nil {
+			return nil, err
+		}
+		list, err := m.require(node, "borders", "compound border")
+		if err != nil {
+			return nil, err
+		}
+		if list.Kind != yaml.SequenceNode {
+			return nil, nodeError(list, "expected a sequence of borders")
+		}
+		borders := make([]border.Border, 0, len(list.Content))
+		for _, one := range list.Content {
+			b, err := decodeBorder(one)
+			if err != nil {
+				return nil, err
+			}
+			borders = append(borders, b)
+		}
+		return border.NewCompound(borders...), nil
+	}
+}
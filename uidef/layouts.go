@@ -0,0 +1,234 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package uidef
+
+import (
+	"github.com/richardwilkes/ux/layout"
+	"github.com/richardwilkes/ux/layout/align"
+	bl "github.com/richardwilkes/ux/layout/border"
+	"github.com/richardwilkes/ux/layout/flex"
+	"github.com/richardwilkes/ux/layout/flexbox"
+	"github.com/richardwilkes/ux/layout/flow"
+	"github.com/richardwilkes/ux/layout/stack"
+	"gopkg.in/yaml.v3"
+)
+
+// Layout type names.
+const (
+	flexLayout    = "flex"
+	flowLayout    = "flow"
+	borderLayout  = "border"
+	stackLayout   = "stack"
+	flexboxLayout = "flexbox"
+)
+
+// decodeLayout returns the type name of the layout and a function that
+// applies it to a target.
+func decodeLayout(node *yaml.Node) (string, func(target layout.Layoutable), error) {
+	m, err := newMapping(node, "layout")
+	if err != nil {
+		return "", nil, err
+	}
+	typeNode, err := m.require(node, "type", "layout")
+	if err != nil {
+		return "", nil, err
+	}
+	names := []string{flexLayout, flowLayout, borderLayout, stackLayout, flexboxLayout}
+	i, err := decodeEnum(typeNode, "layout type", names...)
+	if err != nil {
+		return "", nil, err
+	}
+	kind := names[i]
+	what := kind + " layout"
+	var apply func(target layout.Layoutable)
+	switch kind {
+	case flexLayout:
+		if err = m.check(what, "type", "columns", "hSpacing", "vSpacing", "hAlign", "vAlign", "equalColumns"); err != nil {
+			return "", nil, err
+		}
+		lay := flex.New()
+		err = decodeFields(m, map[string]func(*yaml.Node) error{
+			"columns":      intField(func(v int) { lay.Columns(v) }),
+			"hSpacing":     floatField(func(v float64) { lay.HSpacing(v) }),
+			"vSpacing":     floatField(func(v float64) { lay.VSpacing(v) }),
+			"hAlign":       alignmentField(func(v align.Alignment) { lay.HAlign(v) }),
+			"vAlign":       alignmentField(func(v align.Alignment) { lay.VAlign(v) }),
+			"equalColumns": boolField(func(v bool) { lay.EqualColumns(v) }),
+		})
+		apply = lay.Apply
+	case flowLayout:
+		if err = m.check(what, "type", "hSpacing", "vSpacing"); err != nil {
+			return "", nil, err
+		}
+		lay := flow.New()
+		err = decodeFields(m, map[string]func(*yaml.Node) error{
+			"hSpacing": floatField(func(v float64) { lay.HSpacing(v) }),
+			"vSpacing": floatField(func(v float64) { lay.VSpacing(v) }),
+		})
+		apply = lay.Apply
+	case borderLayout:
+		if err = m.check(what, "type", "hSpacing", "vSpacing"); err != nil {
+			return "", nil, err
+		}
+		lay := bl.New()
+		err = decodeFields(m, map[string]func(*yaml.Node) error{
+			"hSpacing": floatField(func(v float64) { lay.HSpacing(v) }),
+			"vSpacing": floatField(func(v float64) { lay.VSpacing(v) }),
+		})
+		apply = lay.Apply
+	case stackLayout:
+		if err = m.check(what, "type"); err != nil {
+			return "", nil, err
+		}
+		apply = stack.New().Apply
+	case flexboxLayout:
+		if err = m.check(what, "type", "direction", "wrap", "justify", "alignItems", "hSpacing", "vSpacing"); err != nil {
+			return "", nil, err
+		}
+		lay := flexbox.New()
+		err = decodeFields(m, map[string]func(*yaml.Node) error{
+			"direction": func(n *yaml.Node) error {
+				v, err := decodeEnum(n, "direction", "row", "rowReverse", "column", "columnReverse")
+				lay.Direction(flexbox.Direction(v))
+				return err
+			},
+			"wrap": boolField(func(v bool) { lay.Wrap(v) }),
+			"justify": func(n *yaml.Node) error {
+				v, err := decodeEnum(n, "justify", "start", "end", "center", "spaceBetween", "spaceAround", "spaceEvenly")
+				lay.Justify(flexbox.Justify(v))
+				return err
+			},
+			"alignItems": alignmentField(func(v align.Alignment) { lay.AlignItems(v) }),
+			"hSpacing":   floatField(func(v float64) { lay.HSpacing(v) }),
+			"vSpacing":   floatField(func(v float64) { lay.VSpacing(v) }),
+		})
+		apply = lay.Apply
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	return kind, apply, nil
+}
+
+// decodeLayoutData returns a function that applies the layout data to a
+// target whose parent uses the specified type of layout.
+func decodeLayoutData(node *yaml.Node, kind string) (func(target layout.Layoutable), error) {
+	switch kind {
+	case flexLayout:
+		m, err := newMapping(node, "flex layout data")
+		if err != nil {
+			return nil, err
+		}
+		if err = m.check("flex layout data", "hSpan", "vSpan", "hAlign", "vAlign", "hGrab", "vGrab", "sizeHint", "minSize"); err != nil {
+			return nil, err
+		}
+		data := flex.NewData()
+		if err = decodeFields(m, map[string]func(*yaml.Node) error{
+			"hSpan":  intField(func(v int) { data.HSpan(v) }),
+			"vSpan":  intField(func(v int) { data.VSpan(v) }),
+			"hAlign": alignmentField(func(v align.Alignment) { data.HAlign(v) }),
+			"vAlign": alignmentField(func(v align.Alignment) { data.VAlign(v) }),
+			"hGrab":  boolField(func(v bool) { data.HGrab(v) }),
+			"vGrab":  boolField(func(v bool) { data.VGrab(v) }),
+			"sizeHint": func(n *yaml.Node) error {
+				v, err := decodeSize(n)
+				data.SizeHint(v)
+				return err
+			},
+			"minSize": func(n *yaml.Node) error {
+				v, err := decodeSize(n)
+				data.MinSize(v)
+				return err
+			},
+		}); err != nil {
+			return nil, err
+		}
+		return data.Apply, nil
+	case flexboxLayout:
+		m, err := newMapping(node, "flexbox layout data")
+		if err != nil {
+			return nil, err
+		}
+		if err = m.check("flexbox layout data", "grow", "shrink", "basis", "alignSelf"); err != nil {
+			return nil, err
+		}
+		data := flexbox.NewData()
+		if err = decodeFields(m, map[string]func(*yaml.Node) error{
+			"grow":      floatField(func(v float64) { data.Grow(v) }),
+			"shrink":    floatField(func(v float64) { data.Shrink(v) }),
+			"basis":     floatField(func(v float64) { data.Basis(v) }),
+			"alignSelf": alignmentField(func(v align.Alignment) { data.AlignSelf(v) }),
+		}); err != nil {
+			return nil, err
+		}
+		return data.Apply, nil
+	case flowLayout:
+		alignment, err := decodeAlignment(node)
+		if err != nil {
+			return nil, err
+		}
+		return func(target layout.Layoutable) { target.SetLayoutData(alignment) }, nil
+	case borderLayout:
+		s, err := decodeSide(node)
+		if err != nil {
+			return nil, err
+		}
+		return func(target layout.Layoutable) { target.SetLayoutData(s) }, nil
+	case stackLayout:
+		return nil, nodeError(node, "layout data is managed by the stack layout and may not be specified")
+	default:
+		return nil, nodeError(node, "layout data requires the parent to have a layout")
+	}
+}
+
+// decodeFields calls the decoder for each key present in the mapping, in
+// the order they appear.
+func decodeFields(m *mapping, decoders map[string]func(*yaml.Node) error) error {
+	for _, key := range m.order {
+		if decoder, ok := decoders[key]; ok {
+			if err := decoder(m.values[key]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func intField(set func(int)) func(*yaml.Node) error {
+	return func(node *yaml.Node) error {
+		v, err := decodeInt(node)
+		set(v)
+		return err
+	}
+}
+
+func floatField(set func(float64)) func(*yaml.Node) error {
+	return func(node *yaml.Node) error {
+		v, err := decodeFloat64(node)
+		set(v)
+		return err
+	}
+}
+
+func boolField(set func(bool)) func(*yaml.Node) error {
+	return func(node *yaml.Node) error {
+		v, err := decodeBool(node)
+		set(v)
+		return err
+	}
+}
+
+func alignmentField(set func(align.Alignment)) func(*yaml.Node) error {
+	return func(node *yaml.Node) error {
+		v, err := decodeAlignment(node)
+		set(v)
+		return err
+	}
+}
@@ -0,0 +1,311 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+// Package uidef builds panel trees from declarative UI definitions written
+// in YAML or JSON.
+//
+// Each node of a definition is a mapping that names the widget type to
+// create with its "type" key. Valid types are "panel" plus the lowercase
+// names of the widgets, such as "button", "label" and "textfield". Every
+// node may also have these keys:
+//
+//	id:         a name that can be passed to Tree.Widget() to retrieve it
+//	border:     a mapping with a type of "empty", "line" or "compound"
+//	layout:     a mapping with a type of "flex", "flow", "border", "stack"
+//	            or "flexbox", plus the settings for that layout
+//	layoutData: the layout data appropriate for the parent's layout
+//	enabled:    whether the widget is enabled
+//	tooltip:    text to show in a tooltip
+//	children:   a sequence of nodes to add as children
+//
+// The remaining keys are the properties of the widget type, using the same
+// names as the widget's accessors, e.g. "text", "hAlign" or "backgroundInk".
+// Scroll bars and separators also accept "horizontal", list and popup menu
+// widgets accept "items", and scroll areas accept "content" and "behavior".
+package uidef
+
+import (
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/ux"
+	"github.com/richardwilkes/ux/layout"
+	"github.com/richardwilkes/ux/widget/list"
+	"github.com/richardwilkes/ux/widget/popupmenu"
+	"github.com/richardwilkes/ux/widget/scrollarea"
+	"github.com/richardwilkes/ux/widget/scrollarea/behavior"
+	"github.com/richardwilkes/ux/widget/tooltip"
+	"gopkg.in/yaml.v3"
+)
+
+const panelType = "panel"
+
+var commonKeys = []string{"type", "id", "border", "layout", "layoutData", "enabled", "tooltip", "children"}
+
+type propertySetter func(w interface{}, node *yaml.Node) error
+
+type widgetType struct {
+	create     func(horizontal bool) interface{}
+	properties map[string]propertySetter
+	oriented   bool
+}
+
+type panelProvider interface {
+	AsPanel() *ux.Panel
+}
+
+// Tree holds the result of loading a UI definition.
+type Tree struct {
+	root *ux.Panel
+	ids  map[string]interface{}
+}
+
+// Load a UI definition from YAML or JSON.
+func Load(r io.Reader) (*Tree, error) {
+	var doc yaml.Node
+	if err := yaml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, errs.NewWithCause("unable to parse UI definition", err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil, nodeError(&doc, "empty UI definition")
+	}
+	t := &Tree{ids: make(map[string]interface{})}
+	_, panel, err := t.build(doc.Content[0], "")
+	if err != nil {
+		return nil, err
+	}
+	t.root = panel
+	return t, nil
+}
+
+// LoadString loads a UI definition from YAML or JSON held in a string.
+func LoadString(definition string) (*Tree, error) {
+	return Load(strings.NewReader(definition))
+}
+
+// Root returns the root panel.
+func (t *Tree) Root() *ux.Panel {
+	return t.root
+}
+
+// Widget returns the widget that was given the id, such as a *button.Button,
+// or nil if there is no such widget.
+func (t *Tree) Widget(id string) interface{} {
+	return t.ids[id]
+}
+
+// Panel returns the panel of the widget that was given the id, or nil if
+// there is no such widget.
+func (t *Tree) Panel(id string) *ux.Panel {
+	if p, ok := t.ids[id].(panelProvider); ok {
+		return p.AsPanel()
+	}
+	return nil
+}
+
+// IDs returns the ids used within the definition, sorted.
+func (t *Tree) IDs() []string {
+	ids := make([]string, 0, len(t.ids))
+	for id := range t.ids {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (t *Tree) build(node *yaml.Node, parentLayout string) (w interface{}, panel *ux.Panel, err error) {
+	node = resolve(node)
+	var m *mapping
+	if m, err = newMapping(node, "widget"); err != nil {
+		return nil, nil, err
+	}
+	var typeNode *yaml.Node
+	if typeNode, err = m.require(node, "type", "widget"); err != nil {
+		return nil, nil, err
+	}
+	var typeName string
+	if typeName, err = decodeString(typeNode); err != nil {
+		return nil, nil, err
+	}
+	var wt *widgetType
+	if typeName != panelType {
+		var ok bool
+		if wt, ok = widgetTypes[typeName]; !ok {
+			return nil, nil, nodeError(typeNode, "unknown widget type %q; expected one of: %s", typeName, strings.Join(typeNames(), ", "))
+		}
+	}
+	if err = m.check(typeName, allowedKeys(typeName, wt)...); err != nil {
+		return nil, nil, err
+	}
+	if wt == nil {
+		panel = ux.NewPanel()
+		w = panel
+	} else {
+		var horizontal bool
+		if value, ok := m.values["horizontal"]; ok {
+			if horizontal, err = decodeBool(value); err != nil {
+				return nil, nil, err
+			}
+		}
+		w = wt.create(horizontal)
+		panel = w.(panelProvider).AsPanel()
+	}
+	if value, ok := m.values["id"]; ok {
+		var id string
+		if id, err = decodeString(value); err != nil {
+			return nil, nil, err
+		}
+		if _, exists := t.ids[id]; exists {
+			return nil, nil, nodeError(value, "duplicate id %q", id)
+		}
+		t.ids[id] = w
+	}
+	var layoutType string
+	for _, key := range m.order {
+		value := m.values[key]
+		switch key {
+		case "type", "id", "horizontal", "children":
+		case "border":
+			b, err := decodeBorder(value)
+			if err != nil {
+				return nil, nil, err
+			}
+			panel.SetBorder(b)
+		case "layout":
+			var apply func(target layout.Layoutable)
+			if layoutType, apply, err = decodeLayout(value); err != nil {
+				return nil, nil, err
+			}
+			apply(panel)
+		case "layoutData":
+			apply, err := decodeLayoutData(value, parentLayout)
+			if err != nil {
+				return nil, nil, err
+			}
+			apply(panel)
+		case "enabled":
+			enabled, err := decodeBool(value)
+			if err != nil {
+				return nil, nil, err
+			}
+			panel.SetEnabled(enabled)
+		case "tooltip":
+			text, err := decodeString(value)
+			if err != nil {
+				return nil, nil, err
+			}
+			panel.Tooltip = tooltip.NewWithText(text)
+		case "items":
+			if err = t.addItems(w, value); err != nil {
+				return nil, nil, err
+			}
+		case "content", "behavior":
+			if err = t.setScrollContent(w.(*scrollarea.ScrollArea), m); err != nil {
+				return nil, nil, err
+			}
+		default:
+			if err = wt.properties[key](w, value); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	if children, ok := m.values["children"]; ok {
+		if children.Kind != yaml.SequenceNode {
+			return nil, nil, nodeError(children, "expected a sequence of widgets")
+		}
+		for _, one := range children.Content {
+			_, child, err := t.build(one, layoutType)
+			if err != nil {
+				return nil, nil, err
+			}
+			panel.AddChild(child)
+		}
+	}
+	return w, panel, nil
+}
+
+func (t *Tree) addItems(w interface{}, node *yaml.Node) error {
+	if node.Kind != yaml.SequenceNode {
+		return nodeError(node, "expected a sequence of items")
+	}
+	for _, one := range node.Content {
+		item, err := decodeString(one)
+		if err != nil {
+			return err
+		}
+		switch widget := w.(type) {
+		case *list.List:
+			widget.Append(item)
+		case *popupmenu.PopupMenu:
+			widget.AddItem(item)
+		}
+	}
+	if p, ok := w.(*popupmenu.PopupMenu); ok && p.ItemCount() != 0 {
+		p.SelectIndex(0)
+	}
+	return nil
+}
+
+func (t *Tree) setScrollContent(s *scrollarea.ScrollArea, m *mapping) error {
+	if s.Content() != nil {
+		return nil // already handled by the sibling key
+	}
+	value, ok := m.values["content"]
+	if !ok {
+		return nodeError(m.keys["behavior"], "behavior requires content for scrollarea")
+	}
+	var behave behavior.Behavior
+	if behaveValue, hasBehavior := m.values["behavior"]; hasBehavior {
+		i, err := decodeEnum(behaveValue, "behavior", "unmodified", "fillWidth", "fillHeight", "fill", "followsWidth", "followsHeight")
+		if err != nil {
+			return err
+		}
+		behave = behavior.Behavior(i)
+	}
+	_, content, err := t.build(value, "")
+	if err != nil {
+		return err
+	}
+	s.SetContent(content, behave)
+	return nil
+}
+
+func allowedKeys(typeName string, wt *widgetType) []string {
+	keys := append([]string{}, commonKeys...)
+	if wt != nil {
+		if wt.oriented {
+			keys = append(keys, "horizontal")
+		}
+		switch typeName {
+		case "list", "popupmenu":
+			keys = append(keys, "items")
+		case "scrollarea":
+			keys = append(keys, "content", "behavior")
+		}
+		props := make([]string, 0, len(wt.properties))
+		for name := range wt.properties {
+			props = append(props, name)
+		}
+		sort.Strings(props)
+		keys = append(keys, props...)
+	}
+	return keys
+}
+
+func typeNames() []string {
+	names := make([]string, 0, len(widgetTypes)+1)
+	names = append(names, panelType)
+	for name := range widgetTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,74 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package uidef_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/ux/layout/flex"
+	"github.com/richardwilkes/ux/uidef"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadPanels(t *testing.T) {
+	tree, err := uidef.LoadString(`
+type: panel
+id: root
+layout:
+  type: flex
+  columns: 2
+children:
+  - type: panel
+    id: left
+    layoutData:
+      hGrab: true
+  - type: panel
+    id: right
+`)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []string{"left", "right", "root"}, tree.IDs())
+	assert.Equal(t, tree.Root(), tree.Panel("root"))
+	assert.Len(t, tree.Root().Children(), 2)
+	data, ok := tree.Panel("left").LayoutData().(*flex.Data)
+	if assert.True(t, ok) {
+		assert.Contains(t, data.String(), "grab true/false")
+	}
+	assert.Nil(t, tree.Widget("missing"))
+}
+
+func TestLoadJSON(t *testing.T) {
+	tree, err := uidef.LoadString(`{"type": "panel", "children": [{"type": "panel", "id": "child"}]}`)
+	if assert.NoError(t, err) {
+		assert.NotNil(t, tree.Panel("child"))
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	for _, one := range []struct {
+		definition string
+		message    string
+	}{
+		{"type: panel\ncolour: red\n", `line 2, column 1: unknown key "colour" for panel`},
+		{"type: panel\nchildren:\n  - type: panel\n    layoutData: start\n", "line 4, column 17: layout data requires the parent to have a layout"},
+		{"type: panel\nlayout:\n  type: flex\n  colums: 2\n", `line 4, column 3: unknown key "colums" for flex layout`},
+		{"type: gizmo\n", `line 1, column 7: unknown widget type "gizmo"`},
+		{"id: root\n", `line 1, column 1: missing key "type" for widget`},
+		{"type: panel\nchildren:\n  - {type: panel, id: a}\n  - {type: panel, id: a}\n", `line 4, column 23: duplicate id "a"`},
+		{"type: panel\nborder:\n  type: line\n  insets: {top: x}\n", "line 4, column 17: expected a number"},
+		{"type: scrollarea\nbehavior: fill\n", "line 2, column 1: behavior requires content for scrollarea"},
+	} {
+		_, err := uidef.LoadString(one.definition)
+		if assert.Error(t, err, one.definition) {
+			assert.Contains(t, err.Error(), one.message, one.definition)
+		}
+	}
+}
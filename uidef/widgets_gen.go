@@ -0,0 +1,871 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+// Code created from "uidef.go.tmpl" - don't edit by hand
+
+package uidef
+
+import (
+	"github.com/richardwilkes/ux/widget/button"
+	"github.com/richardwilkes/ux/widget/checkbox"
+	"github.com/richardwilkes/ux/widget/inkwell"
+	"github.com/richardwilkes/ux/widget/label"
+	"github.com/richardwilkes/ux/widget/list"
+	"github.com/richardwilkes/ux/widget/popupmenu"
+	"github.com/richardwilkes/ux/widget/radiobutton"
+	"github.com/richardwilkes/ux/widget/scrollarea"
+	"github.com/richardwilkes/ux/widget/scrollbar"
+	"github.com/richardwilkes/ux/widget/separator"
+	"github.com/richardwilkes/ux/widget/textfield"
+	"gopkg.in/yaml.v3"
+)
+
+var widgetTypes = map[string]*widgetType{
+	"button": {
+		create: func(bool) interface{} { return button.New() },
+		properties: map[string]propertySetter{
+			"image": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeImage(node)
+				if err == nil {
+					w.(*button.Button).SetImage(value)
+				}
+				return err
+			},
+			"text": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeString(node)
+				if err == nil {
+					w.(*button.Button).SetText(value)
+				}
+				return err
+			},
+			"font": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeFont(node)
+				if err == nil {
+					w.(*button.Button).SetFont(value)
+				}
+				return err
+			},
+			"backgroundInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*button.Button).SetBackgroundInk(value)
+				}
+				return err
+			},
+			"selectedBackgroundInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*button.Button).SetSelectedBackgroundInk(value)
+				}
+				return err
+			},
+			"focusedBackgroundInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*button.Button).SetFocusedBackgroundInk(value)
+				}
+				return err
+			},
+			"pressedBackgroundInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*button.Button).SetPressedBackgroundInk(value)
+				}
+				return err
+			},
+			"edgeInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*button.Button).SetEdgeInk(value)
+				}
+				return err
+			},
+			"textInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*button.Button).SetTextInk(value)
+				}
+				return err
+			},
+			"pressedTextInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*button.Button).SetPressedTextInk(value)
+				}
+				return err
+			},
+			"gap": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeFloat64(node)
+				if err == nil {
+					w.(*button.Button).SetGap(value)
+				}
+				return err
+			},
+			"cornerRadius": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeFloat64(node)
+				if err == nil {
+					w.(*button.Button).SetCornerRadius(value)
+				}
+				return err
+			},
+			"hMargin": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeFloat64(node)
+				if err == nil {
+					w.(*button.Button).SetHMargin(value)
+				}
+				return err
+			},
+			"vMargin": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeFloat64(node)
+				if err == nil {
+					w.(*button.Button).SetVMargin(value)
+				}
+				return err
+			},
+			"imageOnlyHMargin": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeFloat64(node)
+				if err == nil {
+					w.(*button.Button).SetImageOnlyHMargin(value)
+				}
+				return err
+			},
+			"imageOnlyVMargin": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeFloat64(node)
+				if err == nil {
+					w.(*button.Button).SetImageOnlyVMargin(value)
+				}
+				return err
+			},
+			"clickAnimationTime": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeDuration(node)
+				if err == nil {
+					w.(*button.Button).SetClickAnimationTime(value)
+				}
+				return err
+			},
+			"hAlign": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeAlignment(node)
+				if err == nil {
+					w.(*button.Button).SetHAlign(value)
+				}
+				return err
+			},
+			"vAlign": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeAlignment(node)
+				if err == nil {
+					w.(*button.Button).SetVAlign(value)
+				}
+				return err
+			},
+			"side": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeSide(node)
+				if err == nil {
+					w.(*button.Button).SetSide(value)
+				}
+				return err
+			},
+			"sticky": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeBool(node)
+				if err == nil {
+					w.(*button.Button).SetSticky(value)
+				}
+				return err
+			},
+			"hideBase": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeBool(node)
+				if err == nil {
+					w.(*button.Button).SetHideBase(value)
+				}
+				return err
+			},
+		},
+	},
+	"checkbox": {
+		create: func(bool) interface{} { return checkbox.New() },
+		properties: map[string]propertySetter{
+			"image": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeImage(node)
+				if err == nil {
+					w.(*checkbox.CheckBox).SetImage(value)
+				}
+				return err
+			},
+			"text": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeString(node)
+				if err == nil {
+					w.(*checkbox.CheckBox).SetText(value)
+				}
+				return err
+			},
+			"font": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeFont(node)
+				if err == nil {
+					w.(*checkbox.CheckBox).SetFont(value)
+				}
+				return err
+			},
+			"backgroundInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*checkbox.CheckBox).SetBackgroundInk(value)
+				}
+				return err
+			},
+			"focusedBackgroundInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*checkbox.CheckBox).SetFocusedBackgroundInk(value)
+				}
+				return err
+			},
+			"pressedBackgroundInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*checkbox.CheckBox).SetPressedBackgroundInk(value)
+				}
+				return err
+			},
+			"edgeInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*checkbox.CheckBox).SetEdgeInk(value)
+				}
+				return err
+			},
+			"textInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*checkbox.CheckBox).SetTextInk(value)
+				}
+				return err
+			},
+			"pressedTextInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*checkbox.CheckBox).SetPressedTextInk(value)
+				}
+				return err
+			},
+			"gap": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeFloat64(node)
+				if err == nil {
+					w.(*checkbox.CheckBox).SetGap(value)
+				}
+				return err
+			},
+			"cornerRadius": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeFloat64(node)
+				if err == nil {
+					w.(*checkbox.CheckBox).SetCornerRadius(value)
+				}
+				return err
+			},
+			"clickAnimationTime": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeDuration(node)
+				if err == nil {
+					w.(*checkbox.CheckBox).SetClickAnimationTime(value)
+				}
+				return err
+			},
+			"hAlign": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeAlignment(node)
+				if err == nil {
+					w.(*checkbox.CheckBox).SetHAlign(value)
+				}
+				return err
+			},
+			"vAlign": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeAlignment(node)
+				if err == nil {
+					w.(*checkbox.CheckBox).SetVAlign(value)
+				}
+				return err
+			},
+			"side": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeSide(node)
+				if err == nil {
+					w.(*checkbox.CheckBox).SetSide(value)
+				}
+				return err
+			},
+			"state": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeState(node)
+				if err == nil {
+					w.(*checkbox.CheckBox).SetState(value)
+				}
+				return err
+			},
+		},
+	},
+	"inkwell": {
+		create: func(bool) interface{} { return inkwell.New() },
+		properties: map[string]propertySetter{
+			"backgroundInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*inkwell.InkWell).SetBackgroundInk(value)
+				}
+				return err
+			},
+			"focusedBackgroundInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*inkwell.InkWell).SetFocusedBackgroundInk(value)
+				}
+				return err
+			},
+			"pressedBackgroundInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*inkwell.InkWell).SetPressedBackgroundInk(value)
+				}
+				return err
+			},
+			"edgeInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*inkwell.InkWell).SetEdgeInk(value)
+				}
+				return err
+			},
+			"edgeHighlightInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*inkwell.InkWell).SetEdgeHighlightInk(value)
+				}
+				return err
+			},
+			"imageScale": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeFloat64(node)
+				if err == nil {
+					w.(*inkwell.InkWell).SetImageScale(value)
+				}
+				return err
+			},
+			"contentSize": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeFloat64(node)
+				if err == nil {
+					w.(*inkwell.InkWell).SetContentSize(value)
+				}
+				return err
+			},
+			"cornerRadius": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeFloat64(node)
+				if err == nil {
+					w.(*inkwell.InkWell).SetCornerRadius(value)
+				}
+				return err
+			},
+			"clickAnimationTime": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeDuration(node)
+				if err == nil {
+					w.(*inkwell.InkWell).SetClickAnimationTime(value)
+				}
+				return err
+			},
+		},
+	},
+	"label": {
+		create: func(bool) interface{} { return label.New() },
+		properties: map[string]propertySetter{
+			"image": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeImage(node)
+				if err == nil {
+					w.(*label.Label).SetImage(value)
+				}
+				return err
+			},
+			"text": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeString(node)
+				if err == nil {
+					w.(*label.Label).SetText(value)
+				}
+				return err
+			},
+			"font": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeFont(node)
+				if err == nil {
+					w.(*label.Label).SetFont(value)
+				}
+				return err
+			},
+			"ink": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*label.Label).SetInk(value)
+				}
+				return err
+			},
+			"gap": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeFloat64(node)
+				if err == nil {
+					w.(*label.Label).SetGap(value)
+				}
+				return err
+			},
+			"hAlign": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeAlignment(node)
+				if err == nil {
+					w.(*label.Label).SetHAlign(value)
+				}
+				return err
+			},
+			"vAlign": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeAlignment(node)
+				if err == nil {
+					w.(*label.Label).SetVAlign(value)
+				}
+				return err
+			},
+			"side": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeSide(node)
+				if err == nil {
+					w.(*label.Label).SetSide(value)
+				}
+				return err
+			},
+		},
+	},
+	"list": {
+		create: func(bool) interface{} { return list.New() },
+		properties: map[string]propertySetter{
+			"backgroundInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*list.List).SetBackgroundInk(value)
+				}
+				return err
+			},
+			"alternateBackgroundInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*list.List).SetAlternateBackgroundInk(value)
+				}
+				return err
+			},
+			"selectedBackgroundInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*list.List).SetSelectedBackgroundInk(value)
+				}
+				return err
+			},
+		},
+	},
+	"popupmenu": {
+		create: func(bool) interface{} { return popupmenu.New() },
+		properties: map[string]propertySetter{
+			"font": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeFont(node)
+				if err == nil {
+					w.(*popupmenu.PopupMenu).SetFont(value)
+				}
+				return err
+			},
+			"backgroundInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*popupmenu.PopupMenu).SetBackgroundInk(value)
+				}
+				return err
+			},
+			"focusedBackgroundInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*popupmenu.PopupMenu).SetFocusedBackgroundInk(value)
+				}
+				return err
+			},
+			"pressedBackgroundInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*popupmenu.PopupMenu).SetPressedBackgroundInk(value)
+				}
+				return err
+			},
+			"edgeInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*popupmenu.PopupMenu).SetEdgeInk(value)
+				}
+				return err
+			},
+			"textInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*popupmenu.PopupMenu).SetTextInk(value)
+				}
+				return err
+			},
+			"pressedTextInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*popupmenu.PopupMenu).SetPressedTextInk(value)
+				}
+				return err
+			},
+			"cornerRadius": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeFloat64(node)
+				if err == nil {
+					w.(*popupmenu.PopupMenu).SetCornerRadius(value)
+				}
+				return err
+			},
+			"hMargin": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeFloat64(node)
+				if err == nil {
+					w.(*popupmenu.PopupMenu).SetHMargin(value)
+				}
+				return err
+			},
+			"vMargin": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeFloat64(node)
+				if err == nil {
+					w.(*popupmenu.PopupMenu).SetVMargin(value)
+				}
+				return err
+			},
+		},
+	},
+	"radiobutton": {
+		create: func(bool) interface{} { return radiobutton.New() },
+		properties: map[string]propertySetter{
+			"image": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeImage(node)
+				if err == nil {
+					w.(*radiobutton.RadioButton).SetImage(value)
+				}
+				return err
+			},
+			"text": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeString(node)
+				if err == nil {
+					w.(*radiobutton.RadioButton).SetText(value)
+				}
+				return err
+			},
+			"font": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeFont(node)
+				if err == nil {
+					w.(*radiobutton.RadioButton).SetFont(value)
+				}
+				return err
+			},
+			"backgroundInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*radiobutton.RadioButton).SetBackgroundInk(value)
+				}
+				return err
+			},
+			"focusedBackgroundInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*radiobutton.RadioButton).SetFocusedBackgroundInk(value)
+				}
+				return err
+			},
+			"pressedBackgroundInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*radiobutton.RadioButton).SetPressedBackgroundInk(value)
+				}
+				return err
+			},
+			"edgeInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*radiobutton.RadioButton).SetEdgeInk(value)
+				}
+				return err
+			},
+			"textInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*radiobutton.RadioButton).SetTextInk(value)
+				}
+				return err
+			},
+			"pressedTextInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*radiobutton.RadioButton).SetPressedTextInk(value)
+				}
+				return err
+			},
+			"gap": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeFloat64(node)
+				if err == nil {
+					w.(*radiobutton.RadioButton).SetGap(value)
+				}
+				return err
+			},
+			"cornerRadius": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeFloat64(node)
+				if err == nil {
+					w.(*radiobutton.RadioButton).SetCornerRadius(value)
+				}
+				return err
+			},
+			"clickAnimationTime": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeDuration(node)
+				if err == nil {
+					w.(*radiobutton.RadioButton).SetClickAnimationTime(value)
+				}
+				return err
+			},
+			"hAlign": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeAlignment(node)
+				if err == nil {
+					w.(*radiobutton.RadioButton).SetHAlign(value)
+				}
+				return err
+			},
+			"vAlign": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeAlignment(node)
+				if err == nil {
+					w.(*radiobutton.RadioButton).SetVAlign(value)
+				}
+				return err
+			},
+			"side": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeSide(node)
+				if err == nil {
+					w.(*radiobutton.RadioButton).SetSide(value)
+				}
+				return err
+			},
+		},
+	},
+	"scrollarea": {
+		create: func(bool) interface{} { return scrollarea.New() },
+		properties: map[string]propertySetter{
+			"backgroundInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*scrollarea.ScrollArea).SetBackgroundInk(value)
+				}
+				return err
+			},
+			"focusedBorder": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeBorder(node)
+				if err == nil {
+					w.(*scrollarea.ScrollArea).SetFocusedBorder(value)
+				}
+				return err
+			},
+			"unfocusedBorder": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeBorder(node)
+				if err == nil {
+					w.(*scrollarea.ScrollArea).SetUnfocusedBorder(value)
+				}
+				return err
+			},
+		},
+	},
+	"scrollbar": {
+		oriented: true,
+		create:   func(horizontal bool) interface{} { return scrollbar.New(horizontal) },
+		properties: map[string]propertySetter{
+			"backgroundInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*scrollbar.ScrollBar).SetBackgroundInk(value)
+				}
+				return err
+			},
+			"focusedBackgroundInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*scrollbar.ScrollBar).SetFocusedBackgroundInk(value)
+				}
+				return err
+			},
+			"pressedBackgroundInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*scrollbar.ScrollBar).SetPressedBackgroundInk(value)
+				}
+				return err
+			},
+			"edgeInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*scrollbar.ScrollBar).SetEdgeInk(value)
+				}
+				return err
+			},
+			"markInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*scrollbar.ScrollBar).SetMarkInk(value)
+				}
+				return err
+			},
+			"disabledMarkInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*scrollbar.ScrollBar).SetDisabledMarkInk(value)
+				}
+				return err
+			},
+			"barSize": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeFloat64(node)
+				if err == nil {
+					w.(*scrollbar.ScrollBar).SetBarSize(value)
+				}
+				return err
+			},
+			"initialRepeatDelay": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeDuration(node)
+				if err == nil {
+					w.(*scrollbar.ScrollBar).SetInitialRepeatDelay(value)
+				}
+				return err
+			},
+			"repeatDelay": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeDuration(node)
+				if err == nil {
+					w.(*scrollbar.ScrollBar).SetRepeatDelay(value)
+				}
+				return err
+			},
+		},
+	},
+	"separator": {
+		oriented: true,
+		create:   func(horizontal bool) interface{} { return separator.New(horizontal) },
+		properties: map[string]propertySetter{
+			"fillInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*separator.Separator).SetFillInk(value)
+				}
+				return err
+			},
+		},
+	},
+	"textfield": {
+		create: func(bool) interface{} { return textfield.New() },
+		properties: map[string]propertySetter{
+			"font": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeFont(node)
+				if err == nil {
+					w.(*textfield.TextField).SetFont(value)
+				}
+				return err
+			},
+			"backgroundInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*textfield.TextField).SetBackgroundInk(value)
+				}
+				return err
+			},
+			"disabledBackgroundInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*textfield.TextField).SetDisabledBackgroundInk(value)
+				}
+				return err
+			},
+			"invalidBackgroundInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*textfield.TextField).SetInvalidBackgroundInk(value)
+				}
+				return err
+			},
+			"selectedTextBackgroundInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*textfield.TextField).SetSelectedTextBackgroundInk(value)
+				}
+				return err
+			},
+			"textInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*textfield.TextField).SetTextInk(value)
+				}
+				return err
+			},
+			"selectedTextInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*textfield.TextField).SetSelectedTextInk(value)
+				}
+				return err
+			},
+			"watermarkInk": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeInk(node)
+				if err == nil {
+					w.(*textfield.TextField).SetWatermarkInk(value)
+				}
+				return err
+			},
+			"minimumTextWidth": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeFloat64(node)
+				if err == nil {
+					w.(*textfield.TextField).SetMinimumTextWidth(value)
+				}
+				return err
+			},
+			"blinkRate": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeDuration(node)
+				if err == nil {
+					w.(*textfield.TextField).SetBlinkRate(value)
+				}
+				return err
+			},
+			"watermark": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeString(node)
+				if err == nil {
+					w.(*textfield.TextField).SetWatermark(value)
+				}
+				return err
+			},
+			"secure": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeBool(node)
+				if err == nil {
+					w.(*textfield.TextField).SetSecure(value)
+				}
+				return err
+			},
+			"revealToggle": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeBool(node)
+				if err == nil {
+					w.(*textfield.TextField).SetRevealToggle(value)
+				}
+				return err
+			},
+			"clearButton": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeBool(node)
+				if err == nil {
+					w.(*textfield.TextField).SetClearButton(value)
+				}
+				return err
+			},
+			"focusedBorder": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeBorder(node)
+				if err == nil {
+					w.(*textfield.TextField).SetFocusedBorder(value)
+				}
+				return err
+			},
+			"unfocusedBorder": func(w interface{}, node *yaml.Node) error {
+				value, err := decodeBorder(node)
+				if err == nil {
+					w.(*textfield.TextField).SetUnfocusedBorder(value)
+				}
+				return err
+			},
+		},
+	},
+}
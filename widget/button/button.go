@@ -96,7 +96,8 @@ func (b *Button) DefaultDraw(gc draw.Context, dirty geom.Rect, inLiveResize bool
 	rect.Y += b.VerticalMargin()
 	rect.Width -= b.HorizontalMargin() * 2
 	rect.Height -= b.VerticalMargin() * 2
-	widget.DrawLabel(gc, rect, b.hAlign, b.vAlign, b.text, b.font, b.currentTextInk(), b.image, b.side, b.gap, b.Enabled())
+	dir := b.LayoutDirection()
+	widget.DrawLabel(gc, rect, dir.Alignment(b.hAlign), b.vAlign, b.text, b.font, b.currentTextInk(), b.image, dir.Side(b.side), b.gap, b.Enabled())
 }
 
 func (b *Button) currentBackgroundInk() draw.Ink {
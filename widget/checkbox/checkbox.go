@@ -80,7 +80,9 @@ func (c *CheckBox) boxSize() float64 {
 func (c *CheckBox) DefaultDraw(gc draw.Context, dirty geom.Rect, inLiveResize bool) {
 	rect := c.ContentRect(false)
 	size := c.boxAndLabelSize()
-	switch c.hAlign {
+	dir := c.LayoutDirection()
+	hAlign := dir.Alignment(c.hAlign)
+	switch hAlign {
 	case align.Middle, align.Fill:
 		rect.X = math.Floor(rect.X + (rect.Width-size.Width)/2)
 	case align.End:
@@ -98,9 +100,14 @@ func (c *CheckBox) DefaultDraw(gc draw.Context, dirty geom.Rect, inLiveResize bo
 	boxSize := c.boxSize()
 	if c.image != nil || c.text != "" {
 		r := rect
-		r.X += boxSize + c.gap
+		if !dir.RightToLeft() {
+			r.X += boxSize + c.gap
+		}
 		r.Width -= boxSize + c.gap
-		widget.DrawLabel(gc, r, c.hAlign, c.vAlign, c.text, c.font, c.textInk, c.image, c.side, c.gap, c.Enabled())
+		widget.DrawLabel(gc, r, hAlign, c.vAlign, c.text, c.font, c.textInk, c.image, dir.Side(c.side), c.gap, c.Enabled())
+	}
+	if dir.RightToLeft() {
+		rect.X += rect.Width - boxSize
 	}
 	if rect.Height > boxSize {
 		rect.Y += math.Floor((rect.Height - boxSize) / 2)
@@ -45,5 +45,6 @@ func (l *Label) DefaultSizes(hint geom.Size) (min, pref, max geom.Size) {
 
 // DefaultDraw provides the default drawing.
 func (l *Label) DefaultDraw(gc draw.Context, dirty geom.Rect, inLiveResize bool) {
-	widget.DrawLabel(gc, l.ContentRect(false), l.hAlign, l.vAlign, l.text, l.font, l.ink, l.image, l.side, l.gap, l.Enabled())
+	dir := l.LayoutDirection()
+	widget.DrawLabel(gc, l.ContentRect(false), dir.Alignment(l.hAlign), l.vAlign, l.text, l.font, l.ink, l.image, dir.Side(l.side), l.gap, l.Enabled())
 }
@@ -19,6 +19,7 @@ import (
 	"github.com/richardwilkes/ux/ids"
 	"github.com/richardwilkes/ux/keys"
 	"github.com/richardwilkes/ux/layout"
+	"github.com/richardwilkes/ux/menu"
 )
 
 // List provides a control that allows the user to select from a list of
@@ -53,6 +54,7 @@ func New() *List {
 	l.KeyDownCallback = l.DefaultKeyDown
 	l.CanPerformCmdCallback = l.DefaultCanPerformCmd
 	l.PerformCmdCallback = l.DefaultPerformCmd
+	l.ContextMenuCallback = l.DefaultContextMenu
 	return l
 }
 
@@ -298,6 +300,14 @@ func (l *List) DefaultPerformCmd(source interface{}, id int) {
 	}
 }
 
+// DefaultContextMenu provides the default context menu handling.
+func (l *List) DefaultContextMenu(where geom.Point) ux.ContextMenu {
+	if m := menu.NewEditContextMenu(); m != nil {
+		return m
+	}
+	return nil
+}
+
 // SelectRange selects items from 'start' to 'end', inclusive. If 'add' is
 // true, then any existing selection is added to rather than replaced.
 func (l *List) SelectRange(start, end int, add bool) {
@@ -82,7 +82,9 @@ func (r *RadioButton) DefaultDraw(gc draw.Context, dirty geom.Rect, inLiveResize
 	}
 	rect := r.ContentRect(false)
 	size := r.circleAndLabelSize()
-	switch r.hAlign {
+	dir := r.LayoutDirection()
+	hAlign := dir.Alignment(r.hAlign)
+	switch hAlign {
 	case align.Middle, align.Fill:
 		rect.X = math.Floor(rect.X + (rect.Width-size.Width)/2)
 	case align.End:
@@ -100,9 +102,14 @@ func (r *RadioButton) DefaultDraw(gc draw.Context, dirty geom.Rect, inLiveResize
 	circleSize := r.circleSize()
 	if r.image != nil || r.text != "" {
 		rct := rect
-		rct.X += circleSize + r.gap
+		if !dir.RightToLeft() {
+			rct.X += circleSize + r.gap
+		}
 		rct.Width -= circleSize + r.gap
-		widget.DrawLabel(gc, rct, r.hAlign, r.vAlign, r.text, r.font, r.textInk, r.image, r.side, r.gap, r.Enabled())
+		widget.DrawLabel(gc, rct, hAlign, r.vAlign, r.text, r.font, r.textInk, r.image, dir.Side(r.side), r.gap, r.Enabled())
+	}
+	if dir.RightToLeft() {
+		rect.X += rect.Width - circleSize
 	}
 	if rect.Height > circleSize {
 		rect.Y += math.Floor((rect.Height - circleSize) / 2)
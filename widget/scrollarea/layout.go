@@ -55,6 +55,11 @@ func (l *scrollAreaLayout) Layout() {
 	}
 	area := l.scrollArea.ContentRect(false)
 	visibleSize := area.Size
+	rtl := l.scrollArea.LayoutDirection().RightToLeft()
+	trailingInset := insets.Right
+	if rtl {
+		trailingInset = insets.Left
+	}
 	var contentSize geom.Size
 	var prefContentSize geom.Size
 	if l.scrollArea.content != nil {
@@ -107,7 +112,7 @@ func (l *scrollAreaLayout) Layout() {
 	}
 	if visibleSize.Height < contentSize.Height {
 		visibleSize.Width -= vBarSize.Width
-		if insets.Right >= 1 {
+		if trailingInset >= 1 {
 			visibleSize.Width++
 		}
 		if l.scrollArea.behavior == behavior.FillWidth || l.scrollArea.behavior == behavior.Fill {
@@ -147,10 +152,14 @@ func (l *scrollAreaLayout) Layout() {
 	} else {
 		vBar.RemoveFromParent()
 	}
-	l.scrollArea.view.SetFrameRect(geom.Rect{Point: area.Point, Size: visibleSize})
+	viewRect := geom.Rect{Point: area.Point, Size: visibleSize}
+	if rtl {
+		viewRect.X += area.Width - visibleSize.Width
+	}
+	l.scrollArea.view.SetFrameRect(viewRect)
 	if needHBar {
 		hBarSize.Width = visibleSize.Width
-		barRect := geom.Rect{Point: geom.Point{X: area.X, Y: area.Y + visibleSize.Height}, Size: hBarSize}
+		barRect := geom.Rect{Point: geom.Point{X: viewRect.X, Y: area.Y + visibleSize.Height}, Size: hBarSize}
 		if insets.Left >= 1 {
 			barRect.X--
 			barRect.Width++
@@ -163,6 +172,9 @@ func (l *scrollAreaLayout) Layout() {
 	if needVBar {
 		vBarSize.Height = visibleSize.Height
 		barRect := geom.Rect{Point: geom.Point{X: area.X + visibleSize.Width, Y: area.Y}, Size: vBarSize}
+		if rtl {
+			barRect.X = viewRect.X - vBarSize.Width
+		}
 		if insets.Top >= 1 {
 			barRect.Y--
 			barRect.Height++
@@ -0,0 +1,102 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package textfield
+
+import "github.com/richardwilkes/toolbox/xmath"
+
+// Formatter converts between the raw value of a field and the text that is
+// displayed for it, and decides which characters may be entered.
+type Formatter interface {
+	// Accept returns true if the rune may be appended to the raw value.
+	Accept(raw []rune, ch rune) bool
+	// Format returns the display text for the raw value. The result for a
+	// prefix of the raw value must be a prefix of the result for the whole
+	// raw value.
+	Format(raw string) string
+	// Unformat returns the raw value for the display text, stripping any
+	// decoration that Format added.
+	Unformat(text string) string
+	// Valid returns true if the raw value is acceptable as-is. A field whose
+	// raw value is not valid is marked as invalid.
+	Valid(raw string) bool
+}
+
+// Formatter returns the formatter the field is using, if any.
+func (t *TextField) Formatter() Formatter {
+	return t.formatter
+}
+
+// SetFormatter sets the formatter the field should use. Pass in nil to
+// remove it. The current content is re-run through the new formatter.
+func (t *TextField) SetFormatter(formatter Formatter) *TextField {
+	if t.formatter != formatter {
+		t.formatter = formatter
+		if formatter != nil {
+			t.setFormattedText(string(t.runes))
+		}
+		t.Validate()
+	}
+	return t
+}
+
+// RawText returns the content of the field without any formatting applied.
+// When no formatter is set, this is the same as calling Text().
+func (t *TextField) RawText() string {
+	if t.formatter != nil {
+		return t.formatter.Unformat(string(t.runes))
+	}
+	return string(t.runes)
+}
+
+// rawSplit returns the raw content preceding the start index and following
+// the end index.
+func (t *TextField) rawSplit(start, end int) (before, after []rune) {
+	before = []rune(t.formatter.Unformat(string(t.runes[:start])))
+	all := []rune(t.formatter.Unformat(string(t.runes)))
+	through := len([]rune(t.formatter.Unformat(string(t.runes[:end]))))
+	return before, all[xmath.MinInt(through, len(all)):]
+}
+
+// acceptsRaw returns true if the formatter accepts each rune of the raw value
+// in turn. Checking the whole value, rather than just the rune being added,
+// catches the runes that follow an insertion point being shifted into
+// positions they don't fit.
+func (t *TextField) acceptsRaw(raw []rune) bool {
+	for i, ch := range raw {
+		if !t.formatter.Accept(raw[:i], ch) {
+			return false
+		}
+	}
+	return true
+}
+
+// setFormattedText replaces the content with the text, keeping only the
+// runes the formatter accepts, as if they had been typed in turn.
+func (t *TextField) setFormattedText(text string) {
+	var raw []rune
+	for _, ch := range text {
+		if t.formatter.Accept(raw, ch) {
+			raw = append(raw, ch)
+		}
+	}
+	t.setRaw(raw, len(raw))
+}
+
+// setRaw formats the raw content into the field, placing the cursor after
+// the raw index. Listeners are only notified if the content changed.
+func (t *TextField) setRaw(raw []rune, cursor int) {
+	text := t.formatter.Format(string(raw))
+	changed := text != string(t.runes)
+	t.runes = []rune(text)
+	t.SetSelectionTo(len([]rune(t.formatter.Format(string(raw[:cursor])))))
+	if changed {
+		t.notifyOfModification()
+	}
+}
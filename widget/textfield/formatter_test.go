@@ -0,0 +1,118 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package textfield_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/ux/draw"
+	"github.com/richardwilkes/ux/keys"
+	"github.com/richardwilkes/ux/widget/textfield"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPhoneField() (field *textfield.TextField, modified *int) {
+	modified = new(int)
+	field = textfield.New().SetFont(draw.NewFont(draw.FontDescriptor{Family: "Sans", Size: 12}))
+	field.SetFormatter(textfield.NewMask("(999) 999-9999"))
+	field.ModifiedCallback = func() { *modified++ }
+	return field, modified
+}
+
+func TestFormatterSetText(t *testing.T) {
+	f, modified := newPhoneField()
+	f.SetText("1234567890")
+	assert.Equal(t, "(123) 456-7890", f.Text())
+	assert.Equal(t, "1234567890", f.RawText())
+	assert.Equal(t, 1, *modified)
+	start, end := f.Selection()
+	assert.Equal(t, 14, start)
+	assert.Equal(t, 14, end)
+
+	f.SetText("1234567890")
+	f.SetText("(123) 456-7890")
+	assert.Equal(t, 1, *modified, "unchanged text must not notify")
+
+	f.SetText("12x3")
+	assert.Equal(t, "(123) ", f.Text())
+	assert.Equal(t, 2, *modified)
+
+	f.SetText("xyz")
+	assert.Equal(t, "", f.Text())
+	assert.Equal(t, 3, *modified)
+}
+
+func TestFormatterSetFormatter(t *testing.T) {
+	modified := 0
+	f := textfield.New().SetFont(draw.NewFont(draw.FontDescriptor{Family: "Sans", Size: 12}))
+	f.SetText("1234567890")
+	f.ModifiedCallback = func() { modified++ }
+	f.SetFormatter(textfield.NewMask("(999) 999-9999"))
+	assert.Equal(t, "(123) 456-7890", f.Text())
+	assert.Equal(t, 1, modified)
+
+	f.SetFormatter(textfield.NewMask("(999) 999-9999"))
+	assert.Equal(t, "(123) 456-7890", f.Text())
+	assert.Equal(t, 1, modified, "reformatting to the same text must not notify")
+
+	f.SetFormatter(nil)
+	assert.Equal(t, "(123) 456-7890", f.Text())
+	assert.Equal(t, 1, modified)
+}
+
+func TestFormatterTyping(t *testing.T) {
+	f, modified := newPhoneField()
+	for _, ch := range "123" {
+		f.DefaultKeyDown(0, ch, 0, false)
+	}
+	assert.Equal(t, "(123) ", f.Text())
+	start, _ := f.Selection()
+	assert.Equal(t, 6, start, "caret must move past trailing literals")
+	assert.Equal(t, 3, *modified)
+
+	f.DefaultKeyDown(0, 'x', 0, false)
+	assert.Equal(t, "(123) ", f.Text())
+	assert.Equal(t, 3, *modified, "rejected runes must not notify")
+
+	f.SetSelectionTo(2)
+	f.DefaultKeyDown(0, '9', 0, false)
+	assert.Equal(t, "(192) 3", f.Text())
+	start, _ = f.Selection()
+	assert.Equal(t, 3, start)
+}
+
+func TestFormatterDeleteAcrossLiterals(t *testing.T) {
+	f, modified := newPhoneField()
+	f.SetText("1234567890")
+	*modified = 0
+
+	// Backspace just after the ") " literals removes the last digit before
+	// them.
+	f.SetSelectionTo(6)
+	f.DefaultKeyDown(keys.Backspace.Code, 0, 0, false)
+	assert.Equal(t, "(124) 567-890", f.Text())
+	start, _ := f.Selection()
+	assert.Equal(t, 3, start)
+	assert.Equal(t, 1, *modified)
+
+	// Forward delete just before the ") " literals removes the first digit
+	// after them.
+	f.SetSelectionTo(4)
+	f.DefaultKeyDown(keys.Delete.Code, 0, 0, false)
+	assert.Equal(t, "(124) 678-90", f.Text())
+	assert.Equal(t, "12467890", f.RawText())
+	assert.Equal(t, 2, *modified)
+
+	// Forward delete at the end changes nothing.
+	f.SetSelectionToEnd()
+	f.DefaultKeyDown(keys.Delete.Code, 0, 0, false)
+	assert.Equal(t, "(124) 678-90", f.Text())
+	assert.Equal(t, 2, *modified)
+}
@@ -0,0 +1,140 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package textfield
+
+import (
+	"strings"
+	"unicode"
+)
+
+var _ Formatter = &Mask{}
+
+// Mask is a Formatter that constrains input to a fixed pattern. Within the
+// pattern, these characters accept input:
+//
+//	9  a decimal digit
+//	a  a letter
+//	*  a letter or decimal digit
+//	h  a hexadecimal digit
+//
+// Any other character is a literal separator that is inserted automatically.
+// Precede one of the special characters with a backslash to use it as a
+// literal. For example, "9999-99-99" for a date, "(999) 999-9999" for a phone
+// number or "#hhhhhh" for a hex color.
+type Mask struct {
+	entries []maskEntry
+	slots   int
+}
+
+type maskEntry struct {
+	ch      rune
+	literal bool
+}
+
+// NewMask creates a new Mask from the pattern.
+func NewMask(pattern string) *Mask {
+	m := &Mask{}
+	escaped := false
+	for _, ch := range pattern {
+		switch {
+		case escaped:
+			escaped = false
+			m.entries = append(m.entries, maskEntry{ch: ch, literal: true})
+		case ch == '\\':
+			escaped = true
+		case ch == '9' || ch == 'a' || ch == '*' || ch == 'h':
+			m.entries = append(m.entries, maskEntry{ch: ch})
+			m.slots++
+		default:
+			m.entries = append(m.entries, maskEntry{ch: ch, literal: true})
+		}
+	}
+	return m
+}
+
+// Accept implements Formatter.
+func (m *Mask) Accept(raw []rune, ch rune) bool {
+	slot := 0
+	for _, entry := range m.entries {
+		if !entry.literal {
+			if slot == len(raw) {
+				return entry.fits(ch)
+			}
+			slot++
+		}
+	}
+	return false
+}
+
+// Format implements Formatter. Literal separators that follow the last raw
+// character are included, so that the cursor lands past them.
+func (m *Mask) Format(raw string) string {
+	runes := []rune(raw)
+	var buffer strings.Builder
+	i := 0
+	for _, entry := range m.entries {
+		if entry.literal {
+			if i == 0 && len(runes) == 0 {
+				break
+			}
+			buffer.WriteRune(entry.ch)
+			continue
+		}
+		for i < len(runes) && !entry.fits(runes[i]) {
+			i++
+		}
+		if i >= len(runes) {
+			break
+		}
+		buffer.WriteRune(runes[i])
+		i++
+	}
+	return buffer.String()
+}
+
+// Unformat implements Formatter.
+func (m *Mask) Unformat(text string) string {
+	var buffer strings.Builder
+	i := 0
+	for _, ch := range text {
+		if i >= len(m.entries) {
+			break
+		}
+		if !m.entries[i].literal {
+			buffer.WriteRune(ch)
+		}
+		i++
+	}
+	return buffer.String()
+}
+
+// Valid implements Formatter. An empty value or one that fills every input
+// position of the mask is valid.
+func (m *Mask) Valid(raw string) bool {
+	if raw == "" {
+		return true
+	}
+	return len([]rune(m.Unformat(m.Format(raw)))) == m.slots
+}
+
+func (e maskEntry) fits(ch rune) bool {
+	switch e.ch {
+	case '9':
+		return ch >= '0' && ch <= '9'
+	case 'a':
+		return unicode.IsLetter(ch)
+	case '*':
+		return unicode.IsLetter(ch) || (ch >= '0' && ch <= '9')
+	case 'h':
+		return (ch >= '0' && ch <= '9') || (ch >= 'a' && ch <= 'f') || (ch >= 'A' && ch <= 'F')
+	default:
+		return false
+	}
+}
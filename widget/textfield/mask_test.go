@@ -0,0 +1,40 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package textfield_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/ux/widget/textfield"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMask(t *testing.T) {
+	m := textfield.NewMask("(999) 999-9999")
+	assert.Equal(t, "", m.Format(""))
+	assert.Equal(t, "(12", m.Format("12"))
+	assert.Equal(t, "(123) ", m.Format("123"))
+	assert.Equal(t, "(123) 456-7890", m.Format("1234567890"))
+	assert.Equal(t, "(123) 456-7890", m.Format("12345678901"))
+	assert.Equal(t, "1234567890", m.Unformat("(123) 456-7890"))
+	assert.Equal(t, "123", m.Unformat("(123) "))
+	assert.True(t, m.Accept([]rune("123"), '4'))
+	assert.False(t, m.Accept([]rune("123"), 'x'))
+	assert.False(t, m.Accept([]rune("1234567890"), '1'))
+	assert.True(t, m.Valid(""))
+	assert.False(t, m.Valid("123"))
+	assert.True(t, m.Valid("1234567890"))
+
+	m = textfield.NewMask(`#hhhhhh\9`)
+	assert.Equal(t, "#fF09a", m.Format("fF09a"))
+	assert.Equal(t, "#fF09aB9", m.Format("fF09aB"))
+	assert.False(t, m.Accept(nil, 'g'))
+	assert.Equal(t, "fF09aB", m.Unformat("#fF09aB9"))
+}
@@ -0,0 +1,90 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package textfield
+
+import (
+	"time"
+
+	"github.com/richardwilkes/ux"
+	"github.com/richardwilkes/ux/keys"
+)
+
+// DefaultSearchDelay is the default amount of time a SearchField waits for
+// the text to stop changing before calling its SearchCallback.
+const DefaultSearchDelay = 250 * time.Millisecond
+
+// SearchField provides a single-line text input control for entering search
+// terms. It displays a magnifier icon and a clear button, and calls its
+// SearchCallback once the text has stopped changing for a short while.
+type SearchField struct {
+	TextField
+	SearchCallback func(text string)
+	searchDelay    time.Duration
+	generation     int
+}
+
+// NewSearchField creates a new, empty, search field.
+func NewSearchField() *SearchField {
+	s := &SearchField{searchDelay: DefaultSearchDelay}
+	s.initialize(s)
+	s.search = true
+	s.clearButton = true
+	s.modifiedHook = s.scheduleSearch
+	s.KeyDownCallback = s.DefaultKeyDown
+	return s
+}
+
+// SearchDelay returns the amount of time the field waits for the text to
+// stop changing before calling the SearchCallback.
+func (s *SearchField) SearchDelay() time.Duration {
+	return s.searchDelay
+}
+
+// SetSearchDelay sets the amount of time the field waits for the text to stop
+// changing before calling the SearchCallback. Values less than or equal to
+// zero cause the SearchCallback to be called immediately upon each change.
+func (s *SearchField) SetSearchDelay(delay time.Duration) *SearchField {
+	s.searchDelay = delay
+	return s
+}
+
+// DefaultKeyDown provides the default key down handling. Pressing Escape in a
+// non-empty field clears it and searches immediately.
+func (s *SearchField) DefaultKeyDown(keyCode int, ch rune, mod keys.Modifiers, repeat bool) bool {
+	if keyCode == keys.Escape.Code && !mod.OSMenuCmdModifierDown() && len(s.runes) != 0 {
+		s.SetText("")
+		s.Search()
+		return true
+	}
+	return s.TextField.DefaultKeyDown(keyCode, ch, mod, repeat)
+}
+
+// Search calls the SearchCallback immediately with the current text,
+// cancelling any pending delayed call.
+func (s *SearchField) Search() {
+	s.generation++
+	if s.SearchCallback != nil {
+		s.SearchCallback(s.Text())
+	}
+}
+
+func (s *SearchField) scheduleSearch() {
+	if s.searchDelay <= 0 {
+		s.Search()
+		return
+	}
+	s.generation++
+	generation := s.generation
+	ux.InvokeAfter(func() {
+		if generation == s.generation {
+			s.Search()
+		}
+	}, s.searchDelay)
+}
@@ -24,8 +24,11 @@ import (
 	"github.com/richardwilkes/ux/ids"
 	"github.com/richardwilkes/ux/keys"
 	"github.com/richardwilkes/ux/layout"
+	"github.com/richardwilkes/ux/menu"
 )
 
+const secureRune = '\u2022'
+
 // TextField provides a single-line text input control.
 type TextField struct {
 	ux.Panel
@@ -41,14 +44,25 @@ type TextField struct {
 	showCursor       bool
 	pending          bool
 	extendByWord     bool
+	selecting        bool
 	invalid          bool
+	revealed         bool
+	inAccessory      bool
+	search           bool
+	modifiedHook     func()
+	formatter        Formatter
 }
 
 // New creates a new, empty, text field.
 func New() *TextField {
 	t := &TextField{}
+	t.initialize(t)
+	return t
+}
+
+func (t *TextField) initialize(self interface{}) {
 	t.managed.initialize()
-	t.InitTypeAndID(t)
+	t.InitTypeAndID(self)
 	t.SetBorder(t.unfocusedBorder)
 	t.SetFocusable(true)
 	t.SetSizer(t.DefaultSizes)
@@ -57,18 +71,19 @@ func New() *TextField {
 	t.LostFocusCallback = t.DefaultFocusLost
 	t.MouseDownCallback = t.DefaultMouseDown
 	t.MouseDragCallback = t.DefaultMouseDrag
+	t.MouseUpCallback = t.DefaultMouseUp
 	t.UpdateCursorCallback = t.DefaultUpdateCursor
 	t.KeyDownCallback = t.DefaultKeyDown
 	t.CanPerformCmdCallback = t.DefaultCanPerformCmd
 	t.PerformCmdCallback = t.DefaultPerformCmd
-	return t
+	t.ContextMenuCallback = t.DefaultContextMenu
 }
 
 // DefaultSizes provides the default sizing.
 func (t *TextField) DefaultSizes(hint geom.Size) (min, pref, max geom.Size) {
 	var text string
 	if len(t.runes) != 0 {
-		text = string(t.runes)
+		text = t.displayText(0, len(t.runes))
 	} else {
 		text = "M"
 	}
@@ -77,6 +92,10 @@ func (t *TextField) DefaultSizes(hint geom.Size) (min, pref, max geom.Size) {
 	if pref.Width < minWidth {
 		pref.Width = minWidth
 	}
+	if accessoryWidth := t.accessoryWidth() * float64(t.accessoryCount()); accessoryWidth > 0 {
+		pref.Width += accessoryWidth
+		minWidth += accessoryWidth
+	}
 	if b := t.Border(); b != nil {
 		insets := b.Insets()
 		pref.AddInsets(insets)
@@ -96,7 +115,8 @@ func (t *TextField) DefaultSizes(hint geom.Size) (min, pref, max geom.Size) {
 func (t *TextField) DefaultDraw(gc draw.Context, dirty geom.Rect, inLiveResize bool) {
 	gc.Rect(t.ContentRect(true))
 	gc.Fill(t.currentBackgroundInk())
-	rect := t.ContentRect(false)
+	t.drawAccessories(gc)
+	rect := t.textRect()
 	gc.Rect(rect)
 	gc.Clip()
 	textTop := rect.Y + (rect.Height-t.font.Height())/2
@@ -104,12 +124,12 @@ func (t *TextField) DefaultDraw(gc draw.Context, dirty geom.Rect, inLiveResize b
 	case t.HasSelectionRange():
 		left := rect.X + t.scrollOffset
 		if t.selectionStart > 0 {
-			pre := string(t.runes[:t.selectionStart])
+			pre := t.displayText(0, t.selectionStart)
 			gc.DrawString(left, textTop, t.font, t.textInk, pre)
 			left += t.font.Width(pre)
 		}
-		mid := string(t.runes[t.selectionStart:t.selectionEnd])
-		right := rect.X + t.font.Width(string(t.runes[:t.selectionEnd])) + t.scrollOffset
+		mid := t.displayText(t.selectionStart, t.selectionEnd)
+		right := rect.X + t.font.Width(t.displayText(0, t.selectionEnd)) + t.scrollOffset
 		selRect := geom.Rect{Point: geom.Point{X: left, Y: textTop}, Size: geom.Size{Width: right - left, Height: t.font.Height()}}
 		if t.Focused() {
 			gc.Rect(selRect)
@@ -122,18 +142,18 @@ func (t *TextField) DefaultDraw(gc draw.Context, dirty geom.Rect, inLiveResize b
 		}
 		gc.DrawString(left, textTop, t.font, t.selectedTextInk, mid)
 		if t.selectionStart < len(t.runes) {
-			gc.DrawString(right, textTop, t.font, t.textInk, string(t.runes[t.selectionEnd:]))
+			gc.DrawString(right, textTop, t.font, t.textInk, t.displayText(t.selectionEnd, len(t.runes)))
 		}
 	case len(t.runes) == 0:
 		if t.watermark != "" {
 			gc.DrawString(rect.X, textTop, t.font, t.watermarkInk, t.watermark)
 		}
 	default:
-		gc.DrawString(rect.X+t.scrollOffset, textTop, t.font, t.textInk, string(t.runes))
+		gc.DrawString(rect.X+t.scrollOffset, textTop, t.font, t.textInk, t.displayText(0, len(t.runes)))
 	}
 	if !t.HasSelectionRange() && t.Enabled() && t.Focused() {
 		if t.showCursor {
-			x := rect.X + t.font.Width(string(t.runes[:t.selectionEnd])) + t.scrollOffset
+			x := rect.X + t.font.Width(t.displayText(0, t.selectionEnd)) + t.scrollOffset
 			gc.MoveTo(x, textTop)
 			gc.LineTo(x, textTop+t.font.Height()-1)
 			gc.Stroke(t.textInk)
@@ -142,6 +162,70 @@ func (t *TextField) DefaultDraw(gc draw.Context, dirty geom.Rect, inLiveResize b
 	}
 }
 
+func (t *TextField) drawAccessories(gc draw.Context) {
+	ink := t.watermarkInk
+	gc.Save()
+	gc.SetStrokeWidth(1)
+	if t.search {
+		r := t.accessoryBox(t.searchIconRect())
+		circle := r
+		circle.Width *= 0.7
+		circle.Height *= 0.7
+		gc.Ellipse(circle)
+		gc.Stroke(ink)
+		gc.SetStrokeWidth(1.5)
+		gc.MoveTo(circle.X+circle.Width*0.85, circle.Y+circle.Height*0.85)
+		gc.LineTo(r.X+r.Width, r.Y+r.Height)
+		gc.Stroke(ink)
+		gc.SetStrokeWidth(1)
+	}
+	if t.clearButtonVisible() {
+		r := t.accessoryBox(t.clearButtonRect())
+		gc.Ellipse(r)
+		gc.Fill(ink)
+		r.InsetUniform(r.Width * 0.3)
+		gc.SetStrokeWidth(1.5)
+		gc.MoveTo(r.X, r.Y)
+		gc.LineTo(r.X+r.Width, r.Y+r.Height)
+		gc.MoveTo(r.X, r.Y+r.Height)
+		gc.LineTo(r.X+r.Width, r.Y)
+		gc.Stroke(t.currentBackgroundInk())
+		gc.SetStrokeWidth(1)
+	}
+	if t.hasRevealToggle() {
+		if t.Enabled() {
+			ink = t.textInk
+		}
+		eye := t.accessoryBox(t.revealToggleRect())
+		midY := eye.Y + eye.Height/2
+		gc.BeginPath()
+		gc.MoveTo(eye.X, midY)
+		gc.QuadCurveTo(eye.X+eye.Width/2, eye.Y, eye.X+eye.Width, midY)
+		gc.QuadCurveTo(eye.X+eye.Width/2, eye.Y+eye.Height, eye.X, midY)
+		gc.ClosePath()
+		gc.Stroke(ink)
+		pupil := eye
+		pupil.InsetUniform(eye.Width * 0.35)
+		gc.Ellipse(pupil)
+		gc.Fill(ink)
+		if !t.revealed {
+			gc.MoveTo(eye.X, eye.Y+eye.Height)
+			gc.LineTo(eye.X+eye.Width, eye.Y)
+			gc.Stroke(ink)
+		}
+	}
+	gc.Restore()
+}
+
+// accessoryBox returns the largest square centered within the rect, inset
+// slightly so that strokes stay inside it.
+func (t *TextField) accessoryBox(rect geom.Rect) geom.Rect {
+	size := math.Min(rect.Width, rect.Height)
+	box := geom.Rect{Point: geom.Point{X: rect.X + (rect.Width-size)/2, Y: rect.Y + (rect.Height-size)/2}, Size: geom.Size{Width: size, Height: size}}
+	box.InsetUniform(2)
+	return box
+}
+
 // Invalid returns true if the field is currently marked as invalid.
 func (t *TextField) Invalid() bool {
 	return t.invalid
@@ -201,7 +285,21 @@ func (t *TextField) DefaultFocusLost() {
 func (t *TextField) DefaultMouseDown(where geom.Point, button, clickCount int, mod keys.Modifiers) bool {
 	t.RequestFocus()
 	if button == ux.ButtonLeft {
+		t.inAccessory = false
+		if t.hasRevealToggle() && t.revealToggleRect().ContainsPoint(where) {
+			t.inAccessory = true
+			if t.Enabled() {
+				t.SetRevealed(!t.revealed)
+			}
+			return true
+		}
+		if t.clearButtonVisible() && t.clearButtonRect().ContainsPoint(where) {
+			t.inAccessory = true
+			t.SetText("")
+			return true
+		}
 		t.extendByWord = false
+		t.selecting = true
 		switch clickCount {
 		case 2:
 			start, end := t.findWordAt(t.ToSelectionIndex(where.X))
@@ -229,11 +327,22 @@ func (t *TextField) DefaultMouseDown(where geom.Point, button, clickCount int, m
 		}
 		return true
 	}
+	if button == ux.ButtonMiddle && t.Enabled() {
+		// Paste the primary selection at the click, as is customary on X11.
+		if text := sanitize(clipboard.PrimaryText()); text != "" {
+			t.SetSelectionTo(t.ToSelectionIndex(where.X))
+			t.insertRunes([]rune(text))
+		}
+		return true
+	}
 	return false
 }
 
 // DefaultMouseDrag provides the default mouse drag handling.
 func (t *TextField) DefaultMouseDrag(where geom.Point, button int, mod keys.Modifiers) {
+	if t.inAccessory {
+		return
+	}
 	oldAnchor := t.selectionAnchor
 	pos := t.ToSelectionIndex(where.X)
 	var start, end int
@@ -275,9 +384,17 @@ func (t *TextField) DefaultMouseDrag(where geom.Point, button int, mod keys.Modi
 	t.setSelection(start, end, oldAnchor)
 }
 
+// DefaultMouseUp provides the default mouse up handling.
+func (t *TextField) DefaultMouseUp(where geom.Point, button int, mod keys.Modifiers) {
+	if t.selecting {
+		t.selecting = false
+		t.claimPrimary()
+	}
+}
+
 // DefaultUpdateCursor provides the default cursor update handling.
 func (t *TextField) DefaultUpdateCursor(where geom.Point) *draw.Cursor {
-	if t.Enabled() {
+	if t.Enabled() && !t.overAccessory(where) {
 		return draw.TextCursor
 	}
 	return draw.ArrowCursor
@@ -296,24 +413,21 @@ func (t *TextField) DefaultKeyDown(keyCode int, ch rune, mod keys.Modifiers, rep
 		if t.HasSelectionRange() {
 			t.Delete()
 		} else if t.selectionStart < len(t.runes) {
-			t.runes = append(t.runes[:t.selectionStart], t.runes[t.selectionStart+1:]...)
-			t.notifyOfModification()
+			if t.formatter != nil {
+				before, after := t.rawSplit(t.selectionStart, t.selectionStart)
+				if len(after) != 0 {
+					t.setRaw(append(before, after[1:]...), len(before))
+				}
+			} else {
+				t.runes = append(t.runes[:t.selectionStart], t.runes[t.selectionStart+1:]...)
+				t.notifyOfModification()
+			}
 		}
 		t.MarkForRedraw()
 	case keys.Left.Code, keys.NumpadLeft.Code:
-		extend := mod.ShiftDown()
-		if mod.CommandDown() {
-			t.handleHome(extend)
-		} else {
-			t.handleArrowLeft(extend, mod.OptionDown())
-		}
+		t.handleArrow(false, mod)
 	case keys.Right.Code, keys.NumpadRight.Code:
-		extend := mod.ShiftDown()
-		if mod.CommandDown() {
-			t.handleEnd(extend)
-		} else {
-			t.handleArrowRight(extend, mod.OptionDown())
-		}
+		t.handleArrow(true, mod)
 	case keys.End.Code, keys.NumpadEnd.Code, keys.PageDown.Code, keys.NumpadPageDown.Code, keys.Down.Code, keys.NumpadDown.Code:
 		t.handleEnd(mod.ShiftDown())
 	case keys.Home.Code, keys.NumpadHome.Code, keys.PageUp.Code, keys.NumpadPageUp.Code, keys.Up.Code, keys.NumpadUp.Code:
@@ -322,16 +436,41 @@ func (t *TextField) DefaultKeyDown(keyCode int, ch rune, mod keys.Modifiers, rep
 		if unicode.IsControl(ch) {
 			return false
 		}
-		if t.HasSelectionRange() {
-			t.runes = append(t.runes[:t.selectionStart], t.runes[t.selectionEnd:]...)
-		}
-		t.runes = append(t.runes[:t.selectionStart], append([]rune{ch}, t.runes[t.selectionStart:]...)...)
-		t.SetSelectionTo(t.selectionStart + 1)
-		t.notifyOfModification()
+		t.insertRunes([]rune{ch})
 	}
 	return true
 }
 
+// insertRunes replaces the current selection with the runes. When a
+// formatter is present, runes that would leave it with a raw value it doesn't
+// accept are discarded, and nothing is changed if all of them are.
+func (t *TextField) insertRunes(runes []rune) {
+	if t.formatter != nil {
+		raw, after := t.rawSplit(t.selectionStart, t.selectionEnd)
+		inserted := 0
+		for _, ch := range runes {
+			candidate := make([]rune, 0, len(raw)+1+len(after))
+			candidate = append(append(append(candidate, raw...), ch), after...)
+			if t.acceptsRaw(candidate) {
+				raw = append(raw, ch)
+				inserted++
+			}
+		}
+		if inserted == 0 && len(runes) != 0 {
+			return
+		}
+		cursor := len(raw)
+		t.setRaw(append(raw, after...), cursor)
+		return
+	}
+	if t.HasSelectionRange() {
+		t.runes = append(t.runes[:t.selectionStart], t.runes[t.selectionEnd:]...)
+	}
+	t.runes = append(t.runes[:t.selectionStart], append(runes, t.runes[t.selectionStart:]...)...)
+	t.SetSelectionTo(t.selectionStart + len(runes))
+	t.notifyOfModification()
+}
+
 func (t *TextField) handleHome(extend bool) {
 	if extend {
 		t.setSelection(0, t.selectionEnd, t.selectionEnd)
@@ -348,6 +487,26 @@ func (t *TextField) handleEnd(extend bool) {
 	}
 }
 
+// handleArrow moves the caret towards the end of the text if forward is
+// true, or towards the start if not. The text is always drawn left to right,
+// even in a right-to-left layout, so the arrows keep their visual meaning.
+func (t *TextField) handleArrow(forward bool, mod keys.Modifiers) {
+	extend := mod.ShiftDown()
+	// Moving by word in an obscured field would reveal where the word
+	// boundaries are, so move to the start or end instead.
+	toEdge := mod.CommandDown() || (mod.OptionDown() && t.obscured())
+	switch {
+	case toEdge && forward:
+		t.handleEnd(extend)
+	case toEdge:
+		t.handleHome(extend)
+	case forward:
+		t.handleArrowRight(extend, mod.OptionDown())
+	default:
+		t.handleArrowLeft(extend, mod.OptionDown())
+	}
+}
+
 func (t *TextField) handleArrowLeft(extend, byWord bool) {
 	if t.HasSelectionRange() {
 		if extend {
@@ -455,27 +614,37 @@ func (t *TextField) DefaultPerformCmd(source interface{}, id int) {
 	}
 }
 
-// CanCut returns true if the field has a selection that can be cut.
+// DefaultContextMenu provides the default context menu handling.
+func (t *TextField) DefaultContextMenu(where geom.Point) ux.ContextMenu {
+	if m := menu.NewEditContextMenu(); m != nil {
+		return m
+	}
+	return nil
+}
+
+// CanCut returns true if the field has a selection that can be cut. Secure
+// fields never permit their content to be cut, even while revealed.
 func (t *TextField) CanCut() bool {
-	return t.HasSelectionRange()
+	return !t.secure && t.HasSelectionRange()
 }
 
 // Cut the selected text to the clipboard.
 func (t *TextField) Cut() {
-	if t.HasSelectionRange() {
+	if t.CanCut() {
 		clipboard.SetDataWithType([]byte(t.SelectedText()), datatypes.PlainText)
 		t.Delete()
 	}
 }
 
 // CanCopy returns true if the field has a selection that can be copied.
+// Secure fields never permit their content to be copied, even while revealed.
 func (t *TextField) CanCopy() bool {
-	return t.HasSelectionRange()
+	return !t.secure && t.HasSelectionRange()
 }
 
 // Copy the selected text to the clipboard.
 func (t *TextField) Copy() {
-	if t.HasSelectionRange() {
+	if t.CanCopy() {
 		clipboard.SetDataWithType([]byte(t.SelectedText()), datatypes.PlainText)
 	}
 }
@@ -489,13 +658,7 @@ func (t *TextField) CanPaste() bool {
 // Paste any text on the clipboard into the field.
 func (t *TextField) Paste() {
 	if clipboard.HasType(datatypes.PlainText) {
-		runes := []rune(sanitize(string(clipboard.GetFirstData(datatypes.PlainText))))
-		if t.HasSelectionRange() {
-			t.runes = append(t.runes[:t.selectionStart], t.runes[t.selectionEnd:]...)
-		}
-		t.runes = append(t.runes[:t.selectionStart], append(runes, t.runes[t.selectionStart:]...)...)
-		t.SetSelectionTo(t.selectionStart + len(runes))
-		t.notifyOfModification()
+		t.insertRunes([]rune(sanitize(string(clipboard.GetFirstData(datatypes.PlainText)))))
 	} else if t.HasSelectionRange() {
 		t.Delete()
 	}
@@ -509,6 +672,15 @@ func (t *TextField) CanDelete() bool {
 // Delete removes the currently selected text, if any.
 func (t *TextField) Delete() {
 	if t.CanDelete() {
+		if t.formatter != nil {
+			if t.HasSelectionRange() {
+				t.insertRunes(nil)
+			} else if before, after := t.rawSplit(t.selectionStart, t.selectionStart); len(before) != 0 {
+				t.setRaw(append(before[:len(before)-1], after...), len(before)-1)
+			}
+			t.MarkForRedraw()
+			return
+		}
 		if t.HasSelectionRange() {
 			t.runes = append(t.runes[:t.selectionStart], t.runes[t.selectionEnd:]...)
 			t.SetSelectionTo(t.selectionStart)
@@ -536,30 +708,40 @@ func (t *TextField) Text() string {
 	return string(t.runes)
 }
 
-// SetText sets the content of the field.
+// SetText sets the content of the field. When a formatter is present, the
+// text is run through it as if it had been typed.
 func (t *TextField) SetText(text string) *TextField {
 	text = sanitize(text)
 	if string(t.runes) != text {
-		t.runes = []rune(text)
-		t.SetSelectionToEnd()
-		t.notifyOfModification()
+		if t.formatter != nil {
+			t.setFormattedText(text)
+		} else {
+			t.runes = []rune(text)
+			t.SetSelectionToEnd()
+			t.notifyOfModification()
+		}
 	}
 	return t
 }
 
 func (t *TextField) notifyOfModification() {
 	t.MarkForRedraw()
+	if t.modifiedHook != nil {
+		t.modifiedHook()
+	}
 	if t.ModifiedCallback != nil {
 		t.ModifiedCallback()
 	}
 	t.Validate()
 }
 
-// Validate forces field content validation to be run.
+// Validate forces field content validation to be run. The field is marked
+// invalid if the formatter, when present, rejects the raw value or the
+// ValidateCallback returns false. The ValidateCallback is always called.
 func (t *TextField) Validate() {
-	invalid := false
-	if t.ValidateCallback != nil {
-		invalid = !t.ValidateCallback()
+	invalid := t.formatter != nil && !t.formatter.Valid(t.RawText())
+	if t.ValidateCallback != nil && !t.ValidateCallback() {
+		invalid = true
 	}
 	if invalid != t.invalid {
 		t.invalid = invalid
@@ -571,6 +753,130 @@ func sanitize(text string) string {
 	return strings.NewReplacer("\n", "", "\r", "").Replace(text)
 }
 
+// displayText returns the text that should be drawn for the runes in the
+// range start to end.
+func (t *TextField) displayText(start, end int) string {
+	if t.obscured() {
+		return strings.Repeat(string(secureRune), end-start)
+	}
+	return string(t.runes[start:end])
+}
+
+func (t *TextField) obscured() bool {
+	return t.secure && !t.revealed
+}
+
+// Revealed returns true if the content of a secure field is currently being
+// shown in the clear.
+func (t *TextField) Revealed() bool {
+	return t.revealed
+}
+
+// SetRevealed sets whether the content of a secure field should be shown in
+// the clear. Has no visible effect on fields that are not secure.
+func (t *TextField) SetRevealed(revealed bool) *TextField {
+	if t.revealed != revealed {
+		t.revealed = revealed
+		if t.secure {
+			t.MarkForLayoutAndRedraw()
+			t.autoScroll()
+		}
+	}
+	return t
+}
+
+func (t *TextField) hasRevealToggle() bool {
+	return t.secure && t.revealToggle
+}
+
+func (t *TextField) clearButtonVisible() bool {
+	return t.clearButton && len(t.runes) != 0 && t.Enabled()
+}
+
+// accessoryCount returns the number of accessory slots the field reserves
+// space for. Space for the clear button is reserved even while it is hidden
+// so that the text doesn't shift as it comes and goes.
+func (t *TextField) accessoryCount() int {
+	count := 0
+	if t.search {
+		count++
+	}
+	if t.clearButton {
+		count++
+	}
+	if t.hasRevealToggle() {
+		count++
+	}
+	return count
+}
+
+func (t *TextField) accessoryWidth() float64 {
+	return math.Ceil(t.font.Height()) + layout.DefaultHSpacing
+}
+
+// trailingAccessoryRect returns the rect for the accessory slot at the
+// specified index, counting inward from the trailing edge.
+func (t *TextField) trailingAccessoryRect(index int) geom.Rect {
+	rect := t.ContentRect(false)
+	width := t.accessoryWidth()
+	rect.X += rect.Width - width*float64(index+1) + layout.DefaultHSpacing
+	rect.Width = width - layout.DefaultHSpacing
+	return t.mirror(rect)
+}
+
+func (t *TextField) revealToggleRect() geom.Rect {
+	return t.trailingAccessoryRect(0)
+}
+
+func (t *TextField) clearButtonRect() geom.Rect {
+	if t.hasRevealToggle() {
+		return t.trailingAccessoryRect(1)
+	}
+	return t.trailingAccessoryRect(0)
+}
+
+func (t *TextField) searchIconRect() geom.Rect {
+	rect := t.ContentRect(false)
+	rect.Width = t.accessoryWidth() - layout.DefaultHSpacing
+	return t.mirror(rect)
+}
+
+// mirror flips the rect horizontally within the content rect when the field
+// is laid out right to left, so that the leading and trailing accessories
+// trade sides.
+func (t *TextField) mirror(rect geom.Rect) geom.Rect {
+	if t.LayoutDirection().RightToLeft() {
+		content := t.ContentRect(false)
+		rect.X = content.X + content.X + content.Width - (rect.X + rect.Width)
+	}
+	return rect
+}
+
+func (t *TextField) overAccessory(where geom.Point) bool {
+	return (t.hasRevealToggle() && t.revealToggleRect().ContainsPoint(where)) ||
+		(t.clearButtonVisible() && t.clearButtonRect().ContainsPoint(where))
+}
+
+// textRect returns the area within the content rect used for the text.
+func (t *TextField) textRect() geom.Rect {
+	rect := t.ContentRect(false)
+	width := t.accessoryWidth()
+	if t.search {
+		rect.X += width
+		rect.Width -= width
+	}
+	if t.clearButton {
+		rect.Width -= width
+	}
+	if t.hasRevealToggle() {
+		rect.Width -= width
+	}
+	if rect.Width < 0 {
+		rect.Width = 0
+	}
+	return t.mirror(rect)
+}
+
 // SelectedText returns the currently selected text.
 func (t *TextField) SelectedText() string {
 	return string(t.runes[t.selectionStart:t.selectionEnd])
@@ -640,14 +946,27 @@ func (t *TextField) setSelection(start, end, anchor int) {
 		t.selectionAnchor = anchor
 		t.forceShowUntil = time.Now().Add(t.blinkRate)
 		t.showCursor = true
+		if !t.selecting {
+			// While the mouse is still extending the selection, wait for it
+			// to be released rather than taking the primary selection at
+			// every step.
+			t.claimPrimary()
+		}
 		t.MarkForRedraw()
 		t.ScrollIntoView()
 		t.autoScroll()
 	}
 }
 
+// claimPrimary makes the selected text, if any, the primary selection.
+func (t *TextField) claimPrimary() {
+	if !t.secure && t.selectionStart != t.selectionEnd {
+		clipboard.SetPrimaryText(string(t.runes[t.selectionStart:t.selectionEnd]))
+	}
+}
+
 func (t *TextField) autoScroll() {
-	rect := t.ContentRect(false)
+	rect := t.textRect()
 	if rect.Width > 0 {
 		original := t.scrollOffset
 		if t.selectionStart == t.selectionAnchor {
@@ -693,14 +1012,14 @@ func (t *TextField) autoScroll() {
 
 // ToSelectionIndex returns the rune index for the specified x-coordinate.
 func (t *TextField) ToSelectionIndex(x float64) int {
-	rect := t.ContentRect(false)
-	return t.font.IndexForPosition(x-(rect.X+t.scrollOffset), string(t.runes))
+	rect := t.textRect()
+	return t.font.IndexForPosition(x-(rect.X+t.scrollOffset), t.displayText(0, len(t.runes)))
 }
 
 // FromSelectionIndex returns a location in local coordinates for the
 // specified rune index.
 func (t *TextField) FromSelectionIndex(index int) geom.Point {
-	rect := t.ContentRect(false)
+	rect := t.textRect()
 	x := rect.X + t.scrollOffset
 	top := rect.Y + rect.Height/2
 	if index > 0 {
@@ -708,7 +1027,7 @@ func (t *TextField) FromSelectionIndex(index int) geom.Point {
 		if index > length {
 			index = length
 		}
-		x += t.font.PositionForIndex(index, string(t.runes))
+		x += t.font.PositionForIndex(index, t.displayText(0, len(t.runes)))
 	}
 	return geom.Point{X: x, Y: top}
 }
@@ -720,6 +1039,11 @@ func (t *TextField) findWordAt(pos int) (start, end int) {
 	} else if pos >= length {
 		pos = length - 1
 	}
+	if t.obscured() {
+		// Word boundaries would reveal where the spaces are, so treat the
+		// entire content as a single word.
+		return 0, length
+	}
 	start = pos
 	end = pos
 	if length > 0 && !unicode.IsSpace(t.runes[start]) {
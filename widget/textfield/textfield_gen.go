@@ -31,6 +31,9 @@ type managed struct {
 	minimumTextWidth          float64
 	blinkRate                 time.Duration
 	watermark                 string //nolint:structcheck
+	secure                    bool   //nolint:structcheck
+	revealToggle              bool   //nolint:structcheck
+	clearButton               bool   //nolint:structcheck
 	focusedBorder             border.Border
 	unfocusedBorder           border.Border
 }
@@ -248,6 +251,54 @@ func (t *TextField) SetWatermark(value string) *TextField {
 	return t
 }
 
+// Secure returns whether the content is obscured by drawing a bullet in
+// place of each character. Secure content cannot be cut or copied.
+func (t *TextField) Secure() bool {
+	return t.secure
+}
+
+// SetSecure sets whether the content is obscured by drawing a bullet in
+// place of each character. Secure content cannot be cut or copied.
+func (t *TextField) SetSecure(value bool) *TextField {
+	if t.secure != value {
+		t.secure = value
+		t.MarkForLayoutAndRedraw()
+	}
+	return t
+}
+
+// RevealToggle returns whether a toggle is shown at the trailing edge of a
+// secure field that permits the content to be temporarily revealed.
+func (t *TextField) RevealToggle() bool {
+	return t.revealToggle
+}
+
+// SetRevealToggle sets whether a toggle is shown at the trailing edge of a
+// secure field that permits the content to be temporarily revealed.
+func (t *TextField) SetRevealToggle(value bool) *TextField {
+	if t.revealToggle != value {
+		t.revealToggle = value
+		t.MarkForLayoutAndRedraw()
+	}
+	return t
+}
+
+// ClearButton returns whether a button is shown at the trailing edge of a
+// non-empty field that clears its content.
+func (t *TextField) ClearButton() bool {
+	return t.clearButton
+}
+
+// SetClearButton sets whether a button is shown at the trailing edge of a
+// non-empty field that clears its content.
+func (t *TextField) SetClearButton(value bool) *TextField {
+	if t.clearButton != value {
+		t.clearButton = value
+		t.MarkForLayoutAndRedraw()
+	}
+	return t
+}
+
 // FocusedBorder returns the border to use when focused. Note that the border
 // should present the same insets as the unfocused border or the display will
 // not appear correct.
@@ -0,0 +1,42 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package textfield_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/ux/draw"
+	"github.com/richardwilkes/ux/keys"
+	"github.com/richardwilkes/ux/widget/textfield"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordMovement(t *testing.T) {
+	f := textfield.New().SetFont(draw.NewFont(draw.FontDescriptor{Family: "Sans", Size: 12}))
+	f.SetText("one two three")
+	f.SetSelectionTo(6)
+	f.DefaultKeyDown(keys.Left.Code, 0, keys.OptionModifier, false)
+	start, _ := f.Selection()
+	assert.Equal(t, 4, start)
+	f.DefaultKeyDown(keys.Right.Code, 0, keys.OptionModifier, false)
+	_, end := f.Selection()
+	assert.Equal(t, 7, end)
+
+	// A secure field must not reveal where the word boundaries are.
+	f.SetSecure(true)
+	f.SetSelectionTo(6)
+	f.DefaultKeyDown(keys.Left.Code, 0, keys.OptionModifier, false)
+	start, _ = f.Selection()
+	assert.Equal(t, 0, start)
+	f.SetSelectionTo(6)
+	f.DefaultKeyDown(keys.Right.Code, 0, keys.OptionModifier, false)
+	_, end = f.Selection()
+	assert.Equal(t, 13, end)
+}
@@ -18,12 +18,14 @@ import (
 	"github.com/richardwilkes/ux/clipboard/datatypes"
 	"github.com/richardwilkes/ux/draw"
 	"github.com/richardwilkes/ux/keys"
+	"github.com/richardwilkes/ux/layout"
 )
 
 // Constants for mouse buttons.
 const (
-	ButtonLeft  = 0
-	ButtonRight = 1
+	ButtonLeft   = 0
+	ButtonRight  = 1
+	ButtonMiddle = 2
 )
 
 // StyleMask controls the look and capabilities of a window.
@@ -103,6 +105,7 @@ type Window struct {
 	lastMouseOverPanel   *Panel
 	lastKeyDownPanel     *Panel
 	lastDragPanel        *Panel
+	dragTypes            []datatypes.DataType
 	lastTooltip          *Panel
 	background           draw.Ink
 	lastTooltipShownAt   time.Time
@@ -111,10 +114,25 @@ type Window struct {
 	diacritics           keys.Diacritics
 	wnd                  OSWindow
 	valid                bool
+	layoutDebugging      bool
+	direction            layout.Direction
 }
 
 var windowList []*Window
 
+// MenuKeyDownCallback is exposed as an implementation side-effect and should
+// not be used by clients. When set, it is called for each key down event in a
+// window before the focused panel sees it and should return true if it
+// consumed the event.
+var MenuKeyDownCallback func(wnd *Window, keyCode int, ch rune, mod keys.Modifiers, repeat bool) bool
+
+// KeyBindingCallback is exposed as an implementation side-effect and should
+// not be used by clients. When set, it is called for each key down event in a
+// window before MenuKeyDownCallback and the focused panel see it, with
+// afterFocus set to false, and again with afterFocus set to true if they
+// didn't consume it. It should return true if it consumed the event.
+var KeyBindingCallback func(wnd *Window, keyCode int, ch rune, mod keys.Modifiers, repeat, afterFocus bool) bool
+
 // WindowCount returns the number of windows that are open.
 func WindowCount() int {
 	return len(windowList)
@@ -233,12 +251,17 @@ func (w *Window) AttemptClose() {
 
 // Dispose of the window.
 func (w *Window) Dispose() {
+	if activeDrag != nil && (activeDrag.wnd == w || activeDrag.target == w) {
+		activeDrag.cancel()
+	}
 	if w.WillCloseCallback != nil {
 		w.WillCloseCallback()
 		w.WillCloseCallback = nil
 	}
-	if w.root.content != nil {
-		w.root.content.RemoveFromParent()
+	for _, panel := range []*Panel{w.root.overlay, w.root.menubar, w.root.content} {
+		if panel != nil {
+			panel.RemoveFromParent()
+		}
 	}
 	for i, wnd := range windowList {
 		if w != wnd {
@@ -282,6 +305,40 @@ func (w *Window) SetContent(panel *Panel) {
 	w.MarkForRedraw()
 }
 
+// MenuBar returns the panel being used as the in-window menu bar, if any.
+func (w *Window) MenuBar() *Panel {
+	return w.root.menubar
+}
+
+// SetMenuBar sets the panel to be used as the in-window menu bar. It is
+// placed above the content panel and spans the width of the window. Pass nil
+// to remove it. This is normally only called by the menu package on
+// platforms that draw their own menus.
+func (w *Window) SetMenuBar(bar *Panel) {
+	w.root.setMenuBar(bar)
+	w.ValidateLayout()
+	w.MarkForRedraw()
+}
+
+// Overlay returns the overlay panel, if any.
+func (w *Window) Overlay() *Panel {
+	return w.root.overlay
+}
+
+// SetOverlay sets a panel that covers the entire window, drawing above the
+// menu bar and content panel. While present, it and its children receive all
+// mouse events for the window. The overlay gets the first opportunity to
+// handle key events, after which they are delivered to the focus only if it
+// is within the overlay. The overlay is removed automatically when the window
+// loses the keyboard focus. Pass nil to remove it.
+func (w *Window) SetOverlay(overlay *Panel) {
+	w.ClearTooltip()
+	w.lastMouseOverPanel = nil
+	w.root.setOverlay(overlay)
+	w.ValidateLayout()
+	w.MarkForRedraw()
+}
+
 // ValidateLayout performs any layout that needs to be run by this window or
 // its children.
 func (w *Window) ValidateLayout() {
@@ -371,7 +428,7 @@ func (w *Window) Focus() *Panel {
 func (w *Window) SetFocus(target *Panel) {
 	if target != nil {
 		tw := target.Window()
-		if tw != nil && tw.id == w.id && !target.Is(w.focus) {
+		if tw != nil && tw.id == w.id && !target.Is(w.focus) && !target.inHiddenTree() {
 			if w.focus != nil && w.focus.LostFocusCallback != nil {
 				w.focus.LostFocusCallback()
 			}
@@ -424,8 +481,26 @@ func (w *Window) FocusPrevious() {
 	}
 }
 
+// moveFocusOutOf moves the keyboard focus to the next focusable panel if it
+// is currently the panel or one of its descendants. If there is no other
+// panel to move it to, the window is left without a focus.
+func (w *Window) moveFocusOutOf(panel *Panel) {
+	if w.focusWithin(panel) {
+		w.FocusNext()
+		if w.focusWithin(panel) {
+			if w.focus.LostFocusCallback != nil {
+				w.focus.LostFocusCallback()
+			}
+			w.focus = nil
+		}
+	}
+}
+
 func collectFocusables(current, target *Panel, focusables []*Panel) (match int, result []*Panel) {
 	match = -1
+	if current.hidden {
+		return match, focusables
+	}
 	if current.Focusable() {
 		if current.Is(target) {
 			match = len(focusables)
@@ -482,6 +557,12 @@ func (w *Window) Resizable() bool {
 	return w.style&ResizableWindowMask != 0
 }
 
+// HasInternalMenu returns true if the window may have an in-window menu bar,
+// i.e. it was not created with the NoInternalMenuWindowMask.
+func (w *Window) HasInternalMenu() bool {
+	return w.style&NoInternalMenuWindowMask == 0
+}
+
 // MouseLocation returns the current mouse location relative to this window.
 func (w *Window) MouseLocation() geom.Point {
 	return w.osMouseLocation()
@@ -494,6 +575,33 @@ func (w *Window) Draw(gc draw.Context, dirtyRect geom.Rect, inLiveResize bool) {
 		gc.Rect(dirtyRect)
 		gc.Fill(w.background)
 		w.root.Draw(gc, dirtyRect, inLiveResize)
+		if w.LayoutDebugging() {
+			w.drawLayoutDebugging(gc)
+		}
+		if activeDrag != nil && activeDrag.target == w {
+			activeDrag.draw(gc)
+		}
+	}
+}
+
+// LayoutDirection returns the direction content flows in for this window.
+// If no direction has been set, layout.DefaultDirection is used.
+func (w *Window) LayoutDirection() layout.Direction {
+	if w.direction != layout.InheritDirection {
+		return w.direction
+	}
+	return layout.DirectionOf(nil)
+}
+
+// SetLayoutDirection sets the direction content flows in for this window.
+// Pass in layout.InheritDirection to use layout.DefaultDirection.
+func (w *Window) SetLayoutDirection(direction layout.Direction) {
+	if w.direction != direction {
+		w.direction = direction
+		if w.root != nil {
+			w.root.markTreeForLayout()
+		}
+		w.MarkForRedraw()
 	}
 }
 
@@ -544,6 +652,7 @@ func (w *Window) SetBackground(ink draw.Ink) {
 // RegisterDragTypes registers the data types the window will accept in a
 // drag & drop operation.
 func (w *Window) RegisterDragTypes(dt ...datatypes.DataType) {
+	w.dragTypes = append(w.dragTypes, dt...)
 	w.osRegisterDragTypes(dt...)
 }
 
@@ -621,6 +730,9 @@ func (w *Window) focusGained() {
 
 func (w *Window) focusLost() {
 	w.ClearTooltip()
+	if w.root.overlay != nil {
+		w.SetOverlay(nil)
+	}
 	if w.focus != nil {
 		w.focus.MarkForRedraw()
 	}
@@ -631,6 +743,9 @@ func (w *Window) mouseDown(where geom.Point, button, clickCount int, mod keys.Mo
 		w.ClearTooltip()
 		w.lastMouseDownPanel = nil
 		panel := w.root.PanelAt(where)
+		if isContextMenuClick(button, mod) && w.root.overlay == nil && w.showContextMenu(panel, where) {
+			return
+		}
 		for panel != nil {
 			if panel.Enabled() && panel.MouseDownCallback != nil && panel.MouseDownCallback(panel.PointFromRoot(where), button, clickCount, mod) {
 				w.lastMouseDownPanel = panel
@@ -642,12 +757,21 @@ func (w *Window) mouseDown(where geom.Point, button, clickCount int, mod keys.Mo
 }
 
 func (w *Window) mouseDrag(where geom.Point, button int, mod keys.Modifiers) {
+	if activeDrag != nil && activeDrag.wnd == w {
+		activeDrag.update(w, where, mod)
+		return
+	}
 	if w.lastMouseDownPanel != nil && w.lastMouseDownPanel.MouseDragCallback != nil && w.lastMouseDownPanel.Enabled() {
 		w.lastMouseDownPanel.MouseDragCallback(w.lastMouseDownPanel.PointFromRoot(where), button, mod)
 	}
 }
 
 func (w *Window) mouseUp(where geom.Point, button int, mod keys.Modifiers) {
+	if activeDrag != nil && activeDrag.wnd == w {
+		activeDrag.drop(w, where, mod)
+		w.lastMouseDownPanel = nil
+		return
+	}
 	if w.lastMouseDownPanel != nil && w.lastMouseDownPanel.MouseUpCallback != nil && w.lastMouseDownPanel.Enabled() {
 		w.lastMouseDownPanel.MouseUpCallback(w.lastMouseDownPanel.PointFromRoot(where), button, mod)
 	}
@@ -667,6 +791,9 @@ func (w *Window) mouseEnter(where geom.Point, mod keys.Modifiers) {
 		panel.MouseEnterCallback(panel.PointFromRoot(where), mod)
 	}
 	w.updateTooltipAndCursor(panel, where)
+	if w.LayoutDebugging() && !panel.Is(w.lastMouseOverPanel) {
+		w.MarkForRedraw()
+	}
 	w.lastMouseOverPanel = panel
 }
 
@@ -689,6 +816,9 @@ func (w *Window) mouseExit() {
 		}
 		w.lastMouseOverPanel = nil
 		w.cursor = nil
+		if w.LayoutDebugging() {
+			w.MarkForRedraw()
+		}
 	}
 }
 
@@ -712,17 +842,50 @@ func (w *Window) mouseWheel(where, delta geom.Point, mod keys.Modifiers) {
 func (w *Window) keyDown(keyCode int, ch rune, mod keys.Modifiers, repeat bool) {
 	w.ClearTooltip()
 	w.lastKeyDownPanel = nil
-	if focus := w.Focus(); focus != nil {
+	if activeDrag != nil && keyCode == keys.Escape.Code {
+		activeDrag.cancel()
+		return
+	}
+	overlay := w.root.overlay
+	if overlay != nil {
+		if overlay.KeyDownCallback != nil && overlay.KeyDownCallback(keyCode, ch, mod, repeat) {
+			w.lastKeyDownPanel = overlay
+			return
+		}
+		if !w.focusWithin(overlay) {
+			return
+		}
+	} else {
+		if KeyBindingCallback != nil && KeyBindingCallback(w, keyCode, ch, mod, repeat, false) {
+			return
+		}
+		if MenuKeyDownCallback != nil && MenuKeyDownCallback(w, keyCode, ch, mod, repeat) {
+			return
+		}
+	}
+	focus := w.Focus()
+	if focus != nil {
+		if overlay == nil && !repeat && isContextMenuKey(keyCode, mod) {
+			rect := focus.RectToRoot(focus.ContentRect(false))
+			if w.showContextMenu(focus, rect.Center()) {
+				return
+			}
+		}
 		ch = w.diacritics.ProcessInput(keyCode, ch, mod)
 		panel := focus
-		for panel != nil {
+		for panel != nil && panel != overlay {
 			if panel.Enabled() && panel.KeyDownCallback != nil && panel.KeyDownCallback(keyCode, ch, mod, repeat) {
 				w.lastKeyDownPanel = panel
 				return
 			}
 			panel = panel.parent
 		}
-		if keyCode == keys.Tab.Code && (mod&(keys.AllModifiers&^keys.ShiftModifier)) == 0 {
+	}
+	if overlay == nil {
+		if KeyBindingCallback != nil && KeyBindingCallback(w, keyCode, ch, mod, repeat, true) {
+			return
+		}
+		if focus != nil && keyCode == keys.Tab.Code && (mod&(keys.AllModifiers&^keys.ShiftModifier)) == 0 {
 			if mod.ShiftDown() {
 				w.FocusPrevious()
 			} else {
@@ -732,6 +895,17 @@ func (w *Window) keyDown(keyCode int, ch rune, mod keys.Modifiers, repeat bool)
 	}
 }
 
+// focusWithin returns true if the focus is the panel or one of its
+// descendants.
+func (w *Window) focusWithin(panel *Panel) bool {
+	for p := w.focus; p != nil; p = p.parent {
+		if p == panel {
+			return true
+		}
+	}
+	return false
+}
+
 func (w *Window) keyUp(keyCode int, mod keys.Modifiers) {
 	if w.lastKeyDownPanel != nil && w.lastKeyDownPanel.KeyUpCallback != nil {
 		w.lastKeyDownPanel.KeyUpCallback(keyCode, mod)
@@ -322,7 +322,7 @@ func dragInfoFromNSDraggingInfo(di *ns.DraggingInfo) *DragInfo {
 	info.DragY = height - info.DragY
 	info.DragImageX, info.DragImageY = di.ImageLocation()
 	info.DragImageY = height - info.DragImageY
-	return info
+	return info.withConversions()
 }
 
 type viewDelegate struct {
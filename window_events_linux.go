@@ -0,0 +1,259 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"math"
+	"unicode"
+
+	"github.com/BurntSushi/xgb/xproto"
+	"github.com/BurntSushi/xgbutil"
+	"github.com/BurntSushi/xgbutil/keybind"
+	"github.com/BurntSushi/xgbutil/xevent"
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ux/keys"
+)
+
+const (
+	windowEventMask = xproto.EventMaskButtonPress | xproto.EventMaskButtonRelease | xproto.EventMaskPointerMotion |
+		xproto.EventMaskEnterWindow | xproto.EventMaskLeaveWindow | xproto.EventMaskFocusChange |
+		xproto.EventMaskKeyPress | xproto.EventMaskKeyRelease
+	anyButtonMask       = xproto.ButtonMask1 | xproto.ButtonMask2 | xproto.ButtonMask3
+	multiClickTimeLimit = 500 // milliseconds
+	multiClickSlop      = 4   // pixels
+)
+
+var (
+	lastActiveWindow *Window
+	lastClickTime    xproto.Timestamp
+	lastClickButton  xproto.Button
+	lastClickWhere   geom.Point
+	lastClickCount   int
+	repeatKeycode    xproto.Keycode
+	repeatTime       xproto.Timestamp
+)
+
+func connectWindowEvents(xu *xgbutil.XUtil, id xproto.Window) {
+	xevent.ButtonPressFun(func(xu *xgbutil.XUtil, e xevent.ButtonPressEvent) {
+		if w, ok := nativeWindowMap[e.Event]; ok && w.IsValid() {
+			lastActiveWindow = w
+			where := geom.Point{X: float64(e.EventX), Y: float64(e.EventY)}
+			if delta, isWheel := wheelDelta(e.Detail); isWheel {
+				if w.MouseWheelCallback != nil {
+					w.MouseWheelCallback(where, delta, convertModifiers(e.State))
+				}
+				return
+			}
+			if w.MouseDownCallback != nil {
+				w.MouseDownCallback(where, buttonNumber(e.Detail), clickCount(e), convertModifiers(e.State))
+			}
+		}
+	}).Connect(xu, id)
+	xevent.ButtonReleaseFun(func(xu *xgbutil.XUtil, e xevent.ButtonReleaseEvent) {
+		if w, ok := nativeWindowMap[e.Event]; ok && w.IsValid() && w.MouseUpCallback != nil {
+			if _, isWheel := wheelDelta(e.Detail); !isWheel {
+				w.MouseUpCallback(geom.Point{X: float64(e.EventX), Y: float64(e.EventY)}, buttonNumber(e.Detail), convertModifiers(e.State))
+			}
+		}
+	}).Connect(xu, id)
+	xevent.MotionNotifyFun(func(xu *xgbutil.XUtil, e xevent.MotionNotifyEvent) {
+		if w, ok := nativeWindowMap[e.Event]; ok && w.IsValid() {
+			where := geom.Point{X: float64(e.EventX), Y: float64(e.EventY)}
+			if e.State&anyButtonMask != 0 {
+				if w.MouseDragCallback != nil {
+					w.MouseDragCallback(where, buttonFromState(e.State), convertModifiers(e.State))
+				}
+			} else if w.MouseMoveCallback != nil {
+				w.MouseMoveCallback(where, convertModifiers(e.State))
+			}
+		}
+	}).Connect(xu, id)
+	xevent.EnterNotifyFun(func(xu *xgbutil.XUtil, e xevent.EnterNotifyEvent) {
+		if w, ok := nativeWindowMap[e.Event]; ok && w.IsValid() && w.MouseEnterCallback != nil {
+			w.MouseEnterCallback(geom.Point{X: float64(e.EventX), Y: float64(e.EventY)}, convertModifiers(e.State))
+		}
+	}).Connect(xu, id)
+	xevent.LeaveNotifyFun(func(xu *xgbutil.XUtil, e xevent.LeaveNotifyEvent) {
+		if w, ok := nativeWindowMap[e.Event]; ok && w.IsValid() && w.MouseExitCallback != nil {
+			w.MouseExitCallback()
+		}
+	}).Connect(xu, id)
+	xevent.KeyPressFun(func(xu *xgbutil.XUtil, e xevent.KeyPressEvent) {
+		if w, ok := nativeWindowMap[e.Event]; ok && w.IsValid() && w.KeyDownCallback != nil {
+			repeat := e.Detail == repeatKeycode && e.Time == repeatTime
+			w.KeyDownCallback(int(e.Detail), keyRune(xu, e.State, e.Detail), convertModifiers(e.State), repeat)
+		}
+	}).Connect(xu, id)
+	xevent.KeyReleaseFun(func(xu *xgbutil.XUtil, e xevent.KeyReleaseEvent) {
+		if isAutoRepeat(xu, e) {
+			// Auto-repeat arrives as a release immediately followed by a
+			// press with the same timestamp. Swallow the release and let the
+			// press report itself as a repeat.
+			repeatKeycode = e.Detail
+			repeatTime = e.Time
+			return
+		}
+		repeatKeycode = 0
+		if w, ok := nativeWindowMap[e.Event]; ok && w.IsValid() && w.KeyUpCallback != nil {
+			w.KeyUpCallback(int(e.Detail), convertModifiers(e.State))
+		}
+	}).Connect(xu, id)
+	xevent.FocusInFun(func(xu *xgbutil.XUtil, e xevent.FocusInEvent) {
+		if w, ok := nativeWindowMap[e.Event]; ok && w.IsValid() {
+			lastActiveWindow = w
+			if w.GainedFocusCallback != nil {
+				w.GainedFocusCallback()
+			}
+		}
+	}).Connect(xu, id)
+	xevent.FocusOutFun(func(xu *xgbutil.XUtil, e xevent.FocusOutEvent) {
+		if w, ok := nativeWindowMap[e.Event]; ok && w.IsValid() && w.LostFocusCallback != nil {
+			w.LostFocusCallback()
+		}
+	}).Connect(xu, id)
+	xevent.ClientMessageFun(func(xu *xgbutil.XUtil, e xevent.ClientMessageEvent) {
+		if w, ok := nativeWindowMap[e.Window]; ok && w.IsValid() {
+			handleXdndMessage(w, e.ClientMessageEvent)
+		}
+	}).Connect(xu, id)
+	setupXdnd(id)
+}
+
+// clickCount returns the number of clicks in a row the press represents.
+func clickCount(e xevent.ButtonPressEvent) int {
+	where := geom.Point{X: float64(e.RootX), Y: float64(e.RootY)}
+	if e.Detail == lastClickButton && e.Time-lastClickTime <= multiClickTimeLimit &&
+		math.Abs(where.X-lastClickWhere.X) <= multiClickSlop && math.Abs(where.Y-lastClickWhere.Y) <= multiClickSlop {
+		lastClickCount++
+	} else {
+		lastClickCount = 1
+	}
+	lastClickTime = e.Time
+	lastClickButton = e.Detail
+	lastClickWhere = where
+	return lastClickCount
+}
+
+// isAutoRepeat returns true if the release is immediately followed by a
+// press of the same key at the same time, which is how the X server reports
+// auto-repeat.
+func isAutoRepeat(xu *xgbutil.XUtil, e xevent.KeyReleaseEvent) bool {
+	for _, one := range xevent.Peek(xu) {
+		if press, ok := one.Event.(xproto.KeyPressEvent); ok {
+			return press.Detail == e.Detail && press.Time == e.Time
+		}
+		return false
+	}
+	return false
+}
+
+// keyRune returns the character produced by the key, or 0 if it doesn't
+// produce one.
+func keyRune(xu *xgbutil.XUtil, state uint16, keycode xproto.Keycode) rune {
+	column := byte(0)
+	if state&xproto.ModMaskShift != 0 {
+		column = 1
+	}
+	if state&xproto.ModMask2 != 0 && isKeypadKeysym(keybind.KeysymGet(xu, keycode, 1)) {
+		// Num Lock inverts the effect of Shift on the keypad
+		column ^= 1
+	}
+	sym := keybind.KeysymGet(xu, keycode, column)
+	if sym == 0 && column != 0 {
+		sym = keybind.KeysymGet(xu, keycode, 0)
+	}
+	var ch rune
+	switch {
+	case (sym >= 0x20 && sym <= 0x7e) || (sym >= 0xa0 && sym <= 0xff):
+		// Latin-1 keysyms match their code points
+		ch = rune(sym)
+	case sym >= 0x1000100 && sym <= 0x110ffff:
+		// Unicode keysyms
+		ch = rune(sym - 0x1000000)
+	case sym >= 0xffaa && sym <= 0xffb9:
+		// Keypad operators and digits
+		ch = rune(sym - 0xff80)
+	default:
+		return 0
+	}
+	if state&xproto.ModMaskLock != 0 && unicode.IsLower(ch) {
+		ch = unicode.ToUpper(ch)
+	}
+	return ch
+}
+
+func isKeypadKeysym(sym xproto.Keysym) bool {
+	return sym >= 0xff80 && sym <= 0xffbd
+}
+
+// wheelDelta returns the scroll amount for the button, if it is one of the
+// buttons X11 uses to report mouse wheel movement.
+func wheelDelta(button xproto.Button) (delta geom.Point, isWheel bool) {
+	switch button {
+	case 4:
+		return geom.Point{Y: 1}, true
+	case 5:
+		return geom.Point{Y: -1}, true
+	case 6:
+		return geom.Point{X: 1}, true
+	case 7:
+		return geom.Point{X: -1}, true
+	default:
+		return geom.Point{}, false
+	}
+}
+
+// buttonNumber converts an X11 button into the numbering used by the
+// mouse callbacks: 0 for the left button, 1 for the right and 2 for the
+// middle. Buttons 4 through 7 are the scroll wheel, so the buttons after
+// them, such as back (8) and forward (9), are numbered from 3 onward.
+func buttonNumber(button xproto.Button) int {
+	switch button {
+	case 1:
+		return 0
+	case 2:
+		return 2
+	case 3:
+		return 1
+	default:
+		return int(button) - 5
+	}
+}
+
+func buttonFromState(state uint16) int {
+	switch {
+	case state&xproto.ButtonMask1 != 0:
+		return 0
+	case state&xproto.ButtonMask3 != 0:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func convertModifiers(state uint16) keys.Modifiers {
+	var mod keys.Modifiers
+	if state&xproto.ModMaskLock != 0 {
+		mod |= keys.CapsLockModifier
+	}
+	if state&xproto.ModMaskShift != 0 {
+		mod |= keys.ShiftModifier
+	}
+	if state&xproto.ModMaskControl != 0 {
+		mod |= keys.ControlModifier
+	}
+	if state&xproto.ModMask1 != 0 {
+		mod |= keys.OptionModifier
+	}
+	if state&xproto.ModMask4 != 0 {
+		mod |= keys.CommandModifier
+	}
+	return mod
+}
@@ -0,0 +1,56 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+type windowExitHook struct {
+	f func()
+}
+
+// AddWindowExitHook adds a function to be called when the panel leaves the
+// window it was in, either because it or one of its ancestors was removed
+// from its parent or because the window was disposed. Moving the panel
+// within the same window does not call the function. Call the returned
+// function to remove the hook.
+func (p *Panel) AddWindowExitHook(f func()) (remove func()) {
+	hook := &windowExitHook{f: f}
+	p.windowExitHooks = append(p.windowExitHooks, hook)
+	return func() {
+		for i, one := range p.windowExitHooks {
+			if one == hook {
+				copy(p.windowExitHooks[i:], p.windowExitHooks[i+1:])
+				p.windowExitHooks[len(p.windowExitHooks)-1] = nil
+				p.windowExitHooks = p.windowExitHooks[:len(p.windowExitHooks)-1]
+				break
+			}
+		}
+	}
+}
+
+// windowChanged is called after the panel has been moved within the panel
+// hierarchy and calls the window exit hooks for it and its descendants if
+// it is no longer in the window it was previously in.
+func (p *Panel) windowChanged(previous *Window) {
+	if previous != nil && p.Window() != previous {
+		p.notifyWindowExit()
+	}
+}
+
+func (p *Panel) notifyWindowExit() {
+	if len(p.windowExitHooks) != 0 {
+		hooks := make([]*windowExitHook, len(p.windowExitHooks))
+		copy(hooks, p.windowExitHooks)
+		for _, hook := range hooks {
+			hook.f()
+		}
+	}
+	for _, child := range p.children {
+		child.notifyWindowExit()
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/richardwilkes/toolbox/xmath/geom"
 	"github.com/richardwilkes/ux/clipboard/datatypes"
 	"github.com/richardwilkes/ux/globals"
+	"github.com/richardwilkes/ux/xsel"
 )
 
 var nativeWindowMap = make(map[xproto.Window]*Window)
@@ -30,7 +31,17 @@ var nativeWindowMap = make(map[xproto.Window]*Window)
 type OSWindow = *xwindow.Window
 
 func osKeyWindow() *Window {
-	return nil // RAW: Implement
+	if id, err := ewmh.ActiveWindowGet(globals.X11); err == nil {
+		if w, ok := nativeWindowMap[id]; ok {
+			return w
+		}
+	}
+	// Without a window manager that supports EWMH, fall back to the window
+	// that last received focus or a click.
+	if lastActiveWindow != nil && lastActiveWindow.IsValid() {
+		return lastActiveWindow
+	}
+	return nil
 }
 
 func osAppWindowsToFront() {
@@ -50,7 +61,7 @@ func osNewWindow(title string, frame geom.Rect, styleMask StyleMask) (OSWindow,
 	if err != nil {
 		return nil, errs.Wrap(err)
 	}
-	if err = w.CreateChecked(globals.X11.RootWin(), int(frame.X), int(frame.Y), int(frame.Width), int(frame.Height), xproto.CwBackPixel|xproto.CwEventMask, uint32(0xffffff), xproto.EventMaskButtonRelease); err != nil {
+	if err = w.CreateChecked(globals.X11.RootWin(), int(frame.X), int(frame.Y), int(frame.Width), int(frame.Height), xproto.CwBackPixel|xproto.CwEventMask, uint32(0xffffff), windowEventMask); err != nil {
 		return nil, errs.Wrap(err)
 	}
 	if err = motif.WmHintsSet(globals.X11, w.Id, &motif.Hints{
@@ -71,6 +82,7 @@ func osNewWindow(title string, frame geom.Rect, styleMask StyleMask) (OSWindow,
 			pw.AttemptClose()
 		}
 	})
+	connectWindowEvents(globals.X11, w.Id)
 	w.Map()
 	// For some reason, the initial coordinates are ignored... move it to the
 	// asked for position.
@@ -97,6 +109,7 @@ func (w *Window) osRemoveNativeWindow() {
 func (w *Window) osDispose() {
 	xevent.Detach(globals.X11, w.wnd.Id)
 	mousebind.Detach(globals.X11, w.wnd.Id)
+	xsel.Detach(w.wnd.Id)
 	w.wnd.Destroy()
 	if len(nativeWindowMap) == 0 && (QuitAfterLastWindowClosedCallback == nil || QuitAfterLastWindowClosedCallback()) {
 		AttemptQuit()
@@ -128,7 +141,18 @@ func (w *Window) osContentRect() geom.Rect {
 		jot.Error(errs.Wrap(err))
 		return geom.Rect{}
 	}
-	return fromXRectToRect(r)
+	rect := fromXRectToRect(r)
+	// The geometry is relative to the parent, which will be the window
+	// manager's frame when one is present, so ask for the position relative
+	// to the root window instead.
+	reply, err := xproto.TranslateCoordinates(globals.X11.Conn(), w.wnd.Id, globals.X11.RootWin(), 0, 0).Reply()
+	if err != nil {
+		jot.Error(errs.Wrap(err))
+	} else {
+		rect.X = float64(reply.DstX)
+		rect.Y = float64(reply.DstY)
+	}
+	return rect
 }
 
 func (w *Window) osToFront() {
@@ -0,0 +1,541 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"time"
+
+	"github.com/BurntSushi/xgb/xproto"
+	"github.com/BurntSushi/xgbutil/xprop"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/log/jot"
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ux/clipboard/datatypes"
+	"github.com/richardwilkes/ux/globals"
+	"github.com/richardwilkes/ux/xsel"
+)
+
+const (
+	xdndVersion        = 5
+	xdndMinimumVersion = 3
+	xdndFinishTimeout  = 10 * time.Second
+	xdndDataTimeout    = 10 * time.Second
+	xdndMaxDepth       = 32
+)
+
+// xdndIncoming tracks a drag from another client over one of our windows.
+type xdndIncoming struct {
+	wnd       *Window
+	source    xproto.Window
+	version   uint32
+	atoms     []xproto.Atom
+	types     []datatypes.DataType
+	data      map[string][][]byte
+	where     geom.Point
+	mask      DragOperation
+	operation DragOperation
+	entered   bool
+	completed bool
+	sequence  int
+}
+
+// xdndOutgoing tracks a drag from one of our windows over another client.
+type xdndOutgoing struct {
+	session        *dragSession
+	window         xproto.Window
+	target         xproto.Window
+	version        uint32
+	types          []xproto.Atom
+	pending        geom.Point
+	operation      DragOperation
+	accepted       bool
+	awaitingStatus bool
+	hasPending     bool
+	dropPending    bool
+	dropped        bool
+}
+
+var (
+	xdndIn  *xdndIncoming
+	xdndOut *xdndOutgoing
+)
+
+// setupXdnd prepares the window to take part in XDND drags.
+func setupXdnd(id xproto.Window) {
+	if err := xprop.ChangeProp32(globals.X11, id, "XdndAware", "ATOM", xdndVersion); err != nil {
+		jot.Error(errs.NewWithCause("unable to mark window as XdndAware", err))
+	}
+	xsel.Attach(id)
+}
+
+func xdndActionForOperation(op DragOperation) xproto.Atom {
+	switch op {
+	case DragOperationCopy:
+		return xsel.Atom("XdndActionCopy")
+	case DragOperationMove:
+		return xsel.Atom("XdndActionMove")
+	case DragOperationLink:
+		return xsel.Atom("XdndActionLink")
+	case DragOperationPrivate:
+		return xsel.Atom("XdndActionPrivate")
+	case DragOperationNone:
+		return xproto.AtomNone
+	default:
+		return xsel.Atom("XdndActionCopy")
+	}
+}
+
+func xdndOperationForAction(action xproto.Atom) DragOperation {
+	switch xsel.AtomName(action) {
+	case "XdndActionCopy":
+		return DragOperationCopy
+	case "XdndActionMove":
+		return DragOperationMove
+	case "XdndActionLink":
+		return DragOperationLink
+	case "XdndActionPrivate":
+		return DragOperationPrivate
+	case "XdndActionAsk":
+		return DragOperationGeneric
+	default:
+		return DragOperationNone
+	}
+}
+
+func sendXdndMessage(to xproto.Window, msgType string, data ...uint32) {
+	var d [5]uint32
+	copy(d[:], data)
+	ev := xproto.ClientMessageEvent{
+		Format: 32,
+		Window: to,
+		Type:   xsel.Atom(msgType),
+		Data:   xproto.ClientMessageDataUnionData32New(d[:]),
+	}
+	xproto.SendEvent(globals.X11.Conn(), false, to, xproto.EventMaskNoEvent, string(ev.Bytes()))
+}
+
+func handleXdndMessage(w *Window, e *xproto.ClientMessageEvent) {
+	if e.Format != 32 {
+		return
+	}
+	d := e.Data.Data32
+	switch xsel.AtomName(e.Type) {
+	case "XdndEnter":
+		xdndEnter(w, d)
+	case "XdndPosition":
+		if xdndIn != nil && xdndIn.wnd == w && xdndIn.source == xproto.Window(d[0]) {
+			xdndIn.position(d)
+		}
+	case "XdndLeave":
+		if xdndIn != nil && xdndIn.wnd == w && xdndIn.source == xproto.Window(d[0]) {
+			xdndIn.exit()
+			xdndIn = nil
+		}
+	case "XdndDrop":
+		if xdndIn != nil && xdndIn.wnd == w && xdndIn.source == xproto.Window(d[0]) {
+			xdndIn.drop(xproto.Timestamp(d[2]))
+		}
+	case "XdndStatus":
+		if xdndOut != nil && xdndOut.window == w.wnd.Id && xdndOut.target == xproto.Window(d[0]) {
+			xdndOut.status(d)
+		}
+	case "XdndFinished":
+		if xdndOut != nil && xdndOut.window == w.wnd.Id && xdndOut.target == xproto.Window(d[0]) && xdndOut.dropped {
+			op := xdndOut.operation
+			if xdndOut.version >= 5 {
+				op = DragOperationNone
+				if d[1]&1 != 0 {
+					op = xdndOperationForAction(xproto.Atom(d[2]))
+				}
+			}
+			xdndOut.finish(op)
+		}
+	}
+}
+
+func xdndEnter(w *Window, d []uint32) {
+	if xdndIn != nil {
+		xdndIn.exit()
+	}
+	lastDragSequence++
+	in := &xdndIncoming{
+		wnd:      w,
+		source:   xproto.Window(d[0]),
+		version:  d[1] >> 24,
+		sequence: lastDragSequence,
+	}
+	if d[1]&1 != 0 {
+		reply, err := xproto.GetProperty(globals.X11.Conn(), false, in.source, xsel.Atom("XdndTypeList"), xproto.AtomAtom, 0, 1<<16).Reply()
+		if err != nil {
+			jot.Error(errs.NewWithCause("unable to read XdndTypeList", err))
+		} else {
+			in.atoms = xsel.Atoms(reply.Value)
+		}
+	} else {
+		for _, one := range d[2:5] {
+			if one != 0 {
+				in.atoms = append(in.atoms, xproto.Atom(one))
+			}
+		}
+	}
+	// Keep only the first atom offered for each data type.
+	atoms := make([]xproto.Atom, 0, len(in.atoms))
+	for _, atom := range in.atoms {
+		if dt, ok := xsel.DataTypeForAtom(atom); ok && !in.hasType(dt) {
+			in.types = append(in.types, dt)
+			atoms = append(atoms, atom)
+		}
+	}
+	in.atoms = atoms
+	xdndIn = in
+}
+
+func (in *xdndIncoming) hasType(dataType datatypes.DataType) bool {
+	for _, one := range in.types {
+		if one.UTI == dataType.UTI {
+			return true
+		}
+	}
+	return false
+}
+
+func (in *xdndIncoming) dragInfo() *DragInfo {
+	count := 1
+	if list, ok := in.data[datatypes.FileURL.UTI]; ok {
+		count = len(list)
+	}
+	di := &DragInfo{
+		Sequence:            in.sequence,
+		SourceOperationMask: in.mask,
+		DragX:               in.where.X,
+		DragY:               in.where.Y,
+		DragImageX:          in.where.X,
+		DragImageY:          in.where.Y,
+		ValidItemsForDrop:   count,
+		ItemTypes:           in.types,
+		DataForType: func(dataType datatypes.DataType) [][]byte {
+			return in.data[dataType.UTI]
+		},
+	}
+	return di.withConversions()
+}
+
+func (in *xdndIncoming) position(d []uint32) {
+	rect := in.wnd.ContentRect()
+	in.where = geom.Point{X: float64(int16(d[2]>>16)) - rect.X, Y: float64(int16(d[2]&0xffff)) - rect.Y}
+	in.mask = xdndOperationForAction(xproto.Atom(d[4]))
+	if in.mask == DragOperationNone || in.version < 2 {
+		in.mask = DragOperationCopy
+	}
+	item := make(map[datatypes.DataType][]byte)
+	for _, dt := range in.types {
+		item[dt] = nil
+	}
+	var op DragOperation
+	if len(in.types) != 0 && in.wnd.acceptsDrag([]map[datatypes.DataType][]byte{item}) {
+		di := in.dragInfo()
+		if !in.entered {
+			in.entered = true
+			if in.wnd.DragEnteredCallback != nil {
+				op = in.wnd.DragEnteredCallback(di)
+			}
+		} else if in.wnd.DragUpdatedCallback != nil {
+			op = in.wnd.DragUpdatedCallback(di)
+		}
+	}
+	in.operation = chooseDragOperation(op, in.mask)
+	var flags uint32 = 2 // Keep sending positions, even within our window
+	if in.operation != DragOperationNone {
+		flags |= 1
+	}
+	sendXdndMessage(in.source, "XdndStatus", uint32(in.wnd.wnd.Id), flags, 0, 0, uint32(xdndActionForOperation(in.operation)))
+}
+
+func (in *xdndIncoming) exit() {
+	if in.entered && in.wnd.DragExitedCallback != nil {
+		in.wnd.DragExitedCallback()
+	}
+	in.entered = false
+}
+
+func (in *xdndIncoming) drop(when xproto.Timestamp) {
+	if in.operation == DragOperationNone || len(in.atoms) == 0 {
+		in.complete()
+		return
+	}
+	in.data = make(map[string][][]byte)
+	pending := len(in.atoms)
+	selection := xsel.Atom("XdndSelection")
+	for i, atom := range in.atoms {
+		dt := in.types[i]
+		xsel.Request(in.wnd.wnd.Id, selection, atom, when, func(data []byte, ok bool) {
+			if ok {
+				if dt.UTI == datatypes.FileURL.UTI {
+					in.data[dt.UTI] = xsel.SplitURIList(data)
+				} else {
+					in.data[dt.UTI] = [][]byte{data}
+				}
+			}
+			pending--
+			if pending == 0 {
+				in.complete()
+			}
+		})
+	}
+	if !in.completed {
+		InvokeAfter(func() {
+			if !in.completed {
+				jot.Warn("timed out waiting for XdndSelection data")
+				xsel.CancelRequests(in.wnd.wnd.Id, selection)
+				in.complete()
+			}
+		}, xdndDataTimeout)
+	}
+}
+
+// complete finishes the drop with whatever data has arrived and tells the
+// source the outcome. Only the first call has any effect.
+func (in *xdndIncoming) complete() {
+	if in.completed {
+		return
+	}
+	in.completed = true
+	if xdndIn == in {
+		xdndIn = nil
+	}
+	accepted := false
+	if in.operation != DragOperationNone && in.wnd.IsValid() {
+		di := in.dragInfo()
+		if in.wnd.DropIsAcceptableCallback != nil && in.wnd.DropIsAcceptableCallback(di) &&
+			in.wnd.DropCallback != nil && in.wnd.DropCallback(di) {
+			accepted = true
+			if in.wnd.DropFinishedCallback != nil {
+				in.wnd.DropFinishedCallback(di)
+			}
+		}
+	}
+	if !accepted {
+		in.exit()
+	}
+	var flags uint32
+	action := xproto.Atom(xproto.AtomNone)
+	if accepted {
+		flags = 1
+		action = xdndActionForOperation(in.operation)
+	}
+	sendXdndMessage(in.source, "XdndFinished", uint32(in.wnd.wnd.Id), flags, uint32(action))
+	if in.wnd.IsValid() && in.wnd.DragEndedCallback != nil {
+		in.wnd.DragEndedCallback()
+	}
+}
+
+func (s *dragSession) osExternalUpdate(screen geom.Point) DragOperation {
+	if !s.wnd.IsValid() {
+		return DragOperationNone
+	}
+	if xdndOut == nil || xdndOut.session != s {
+		xdndOut = &xdndOutgoing{session: s, window: s.wnd.wnd.Id}
+	}
+	out := xdndOut
+	target, version := findXdndTarget(screen)
+	if target != out.target {
+		out.leave()
+		if target != xproto.WindowNone {
+			out.enter(target, version)
+		}
+	}
+	if out.target == xproto.WindowNone {
+		return DragOperationNone
+	}
+	out.sendPosition(screen)
+	return out.operation
+}
+
+func (s *dragSession) osExternalLeave() {
+	if xdndOut != nil && xdndOut.session == s && !xdndOut.dropped && !xdndOut.dropPending {
+		xdndOut.leave()
+	}
+}
+
+func (s *dragSession) osExternalDrop() bool {
+	out := xdndOut
+	if out == nil || out.session != s {
+		return false
+	}
+	if out.target == xproto.WindowNone || (!out.accepted && !out.awaitingStatus) {
+		out.leave()
+		xdndOut = nil
+		return false
+	}
+	if out.awaitingStatus {
+		// The target hasn't answered the latest position yet, so whether it
+		// will accept the drop isn't known. Decide once it does.
+		out.dropPending = true
+	} else {
+		out.drop()
+	}
+	InvokeAfter(func() {
+		if xdndOut == out {
+			if out.dropped {
+				jot.Warn("timed out waiting for XdndFinished")
+			} else {
+				jot.Warn("timed out waiting for XdndStatus")
+				out.leave()
+			}
+			out.finish(DragOperationNone)
+		}
+	}, xdndFinishTimeout)
+	return true
+}
+
+// findXdndTarget returns the XdndAware window of another client that is
+// under the screen location, along with the protocol version to use with it.
+func findXdndTarget(screen geom.Point) (target xproto.Window, version uint32) {
+	conn := globals.X11.Conn()
+	root := globals.X11.RootWin()
+	parent := root
+	for depth := 0; depth < xdndMaxDepth; depth++ {
+		reply, err := xproto.TranslateCoordinates(conn, root, parent, int16(screen.X), int16(screen.Y)).Reply()
+		if err != nil || reply.Child == xproto.WindowNone {
+			break
+		}
+		parent = reply.Child
+		if _, ours := nativeWindowMap[parent]; ours {
+			// Our own windows are handled without going through the server.
+			break
+		}
+		if v, err := xprop.PropValNum(xprop.GetProperty(globals.X11, parent, "XdndAware")); err == nil && v >= xdndMinimumVersion {
+			if v > xdndVersion {
+				v = xdndVersion
+			}
+			return parent, uint32(v)
+		}
+	}
+	return xproto.WindowNone, 0
+}
+
+func (out *xdndOutgoing) enter(target xproto.Window, version uint32) {
+	if out.types == nil {
+		out.types = make([]xproto.Atom, 0)
+		seen := make(map[xproto.Atom]bool)
+		for _, item := range out.session.source.Items {
+			for dt := range item {
+				for _, atom := range xsel.AtomsForDataType(dt) {
+					if !seen[atom] {
+						seen[atom] = true
+						out.types = append(out.types, atom)
+					}
+				}
+			}
+		}
+		if len(out.types) > 3 {
+			xproto.ChangeProperty(globals.X11.Conn(), xproto.PropModeReplace, out.window, xsel.Atom("XdndTypeList"), xproto.AtomAtom, 32, uint32(len(out.types)), xsel.AtomsToBytes(out.types))
+		}
+		xsel.Own(out.window, xsel.Atom("XdndSelection"), out.types, out.provide, nil)
+	}
+	out.target = target
+	out.version = version
+	out.accepted = false
+	out.awaitingStatus = false
+	out.hasPending = false
+	out.operation = DragOperationNone
+	data := make([]uint32, 5)
+	data[0] = uint32(out.window)
+	data[1] = version << 24
+	if len(out.types) > 3 {
+		data[1] |= 1
+	}
+	for i := 0; i < 3 && i < len(out.types); i++ {
+		data[2+i] = uint32(out.types[i])
+	}
+	sendXdndMessage(target, "XdndEnter", data...)
+}
+
+func (out *xdndOutgoing) leave() {
+	if out.target != xproto.WindowNone {
+		sendXdndMessage(out.target, "XdndLeave", uint32(out.window))
+		out.target = xproto.WindowNone
+	}
+	out.accepted = false
+	out.awaitingStatus = false
+	out.hasPending = false
+	out.operation = DragOperationNone
+}
+
+// sendPosition sends the position to the target. The protocol doesn't permit
+// another position to be sent until the target has answered the previous one
+// with XdndStatus, so while waiting, only the latest position is kept and is
+// sent once the answer arrives.
+func (out *xdndOutgoing) sendPosition(screen geom.Point) {
+	if out.awaitingStatus {
+		out.pending = screen
+		out.hasPending = true
+		return
+	}
+	out.awaitingStatus = true
+	out.hasPending = false
+	action := xdndActionForOperation(chooseDragOperation(DragOperationEvery, out.session.operationMask()))
+	sendXdndMessage(out.target, "XdndPosition", uint32(out.window), 0, uint32(int16(screen.X))<<16|uint32(uint16(int16(screen.Y))), uint32(xproto.TimeCurrentTime), uint32(action))
+}
+
+func (out *xdndOutgoing) drop() {
+	out.dropPending = false
+	out.dropped = true
+	sendXdndMessage(out.target, "XdndDrop", uint32(out.window), 0, uint32(xproto.TimeCurrentTime))
+}
+
+func (out *xdndOutgoing) status(d []uint32) {
+	out.accepted = d[1]&1 != 0
+	out.operation = DragOperationNone
+	if out.accepted {
+		out.operation = chooseDragOperation(xdndOperationForAction(xproto.Atom(d[4])), out.session.operationMask())
+		if out.operation == DragOperationNone {
+			out.operation = chooseDragOperation(DragOperationEvery, out.session.operationMask())
+		}
+	}
+	if out.session.target == nil {
+		out.session.operation = out.operation
+	}
+	out.awaitingStatus = false
+	switch {
+	case out.hasPending:
+		out.sendPosition(out.pending)
+	case out.dropPending:
+		if out.accepted {
+			out.drop()
+		} else {
+			out.leave()
+			out.finish(DragOperationNone)
+		}
+	}
+}
+
+func (out *xdndOutgoing) provide(target xproto.Atom) ([]byte, bool) {
+	dt, ok := xsel.DataTypeForAtom(target)
+	if !ok {
+		return nil, false
+	}
+	data := out.session.dataForType(dt)
+	if len(data) == 0 {
+		return nil, false
+	}
+	if dt.UTI == datatypes.FileURL.UTI {
+		return xsel.JoinURIList(data), true
+	}
+	return data[0], true
+}
+
+func (out *xdndOutgoing) finish(op DragOperation) {
+	if xdndOut == out {
+		xdndOut = nil
+	}
+	xsel.Disown(xsel.Atom("XdndSelection"))
+	out.session.finish(op)
+}
@@ -0,0 +1,225 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+// +build linux
+
+// Command xdndharness exercises XDND between two copies of a ux application.
+// Drags between windows of the same process don't go through the X server,
+// so the harness starts a second copy of itself to act as the drop target,
+// then uses the XTEST extension to drag text from its own window to the
+// target's window. It exits with a status of 0 if the text arrived intact
+// and both sides saw the drag complete, or 1 otherwise.
+//
+// It is intended to be run against a private X server, such as:
+//
+//	xvfb-run -s "-screen 0 1024x768x24" go run ./xdndharness
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/xgb/xproto"
+	"github.com/BurntSushi/xgb/xtest"
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ux"
+	"github.com/richardwilkes/ux/clipboard/datatypes"
+	"github.com/richardwilkes/ux/draw"
+	"github.com/richardwilkes/ux/globals"
+	"github.com/richardwilkes/ux/keys"
+)
+
+const (
+	payload   = "XDND harness payload ✓"
+	timeout   = 30 * time.Second
+	stepDelay = 20 * time.Millisecond
+	steps     = 20
+)
+
+var (
+	targetMode bool
+	dropped    = make(chan string, 1)
+	ended      = make(chan bool, 1)
+	dragDone   = make(chan ux.DragOperation, 1)
+)
+
+func main() {
+	runtime.LockOSThread()
+	flag.BoolVar(&targetMode, "target", false, "act as the drop target")
+	flag.Parse()
+	if os.Getenv("DISPLAY") == "" {
+		fmt.Fprintln(os.Stderr, "FAIL: DISPLAY is not set; run under Xvfb")
+		os.Exit(1)
+	}
+	if targetMode {
+		ux.WillFinishStartupCallback = startTarget
+	} else {
+		ux.WillFinishStartupCallback = startSource
+	}
+	ux.Start() // Never returns
+}
+
+func newWindow(title string, x float64, color draw.Color) (wnd *ux.Window, panel *ux.Panel) {
+	wnd, err := ux.NewWindow(title, geom.Rect{Point: geom.Point{X: x, Y: 40}, Size: geom.Size{Width: 200, Height: 150}}, ux.StdWindowMask)
+	if err != nil {
+		fail(err.Error())
+	}
+	panel = wnd.Content()
+	panel.DrawCallback = func(gc draw.Context, dirty geom.Rect, inLiveResize bool) {
+		gc.Rect(dirty)
+		gc.Fill(color)
+	}
+	wnd.ToFront()
+	return wnd, panel
+}
+
+// startTarget creates the window that accepts the drop. Once it is showing,
+// the screen location of its center is written to stdout for the source to
+// read. The text that is dropped is written to stdout as well, followed by
+// a note once the drag has ended.
+func startTarget() {
+	wnd, panel := newWindow("XDND Target", 400, draw.LightGray)
+	wnd.RegisterDragTypes(datatypes.PlainText)
+	panel.DragEnteredCallback = func(di *ux.DragInfo) ux.DragOperation {
+		if di.HasType(datatypes.PlainText) {
+			return ux.DragOperationCopy
+		}
+		return ux.DragOperationNone
+	}
+	panel.DragUpdatedCallback = panel.DragEnteredCallback
+	panel.DropIsAcceptableCallback = func(di *ux.DragInfo) bool { return di.HasType(datatypes.PlainText) }
+	panel.DropCallback = func(di *ux.DragInfo) bool {
+		data := di.DataForType(datatypes.PlainText)
+		if len(data) == 0 {
+			fmt.Println("dropped-empty")
+			return false
+		}
+		fmt.Printf("dropped %s\n", data[0])
+		return true
+	}
+	panel.DragEndedCallback = func() {
+		fmt.Println("ended")
+		// Give XdndFinished time to reach the source before going away.
+		ux.InvokeAfter(func() { os.Exit(0) }, 500*time.Millisecond)
+	}
+	ux.InvokeAfter(func() {
+		center := wnd.ContentRect().Center()
+		fmt.Printf("ready %d %d\n", int(center.X), int(center.Y))
+	}, 500*time.Millisecond)
+}
+
+// startSource creates the window the drag starts from, launches the target
+// and waits for it to report the outcome.
+func startSource() {
+	if err := xtest.Init(globals.X11.Conn()); err != nil {
+		fail("XTEST is not available: " + err.Error())
+	}
+	wnd, panel := newWindow("XDND Source", 20, draw.LightBlue)
+	started := false
+	panel.MouseDownCallback = func(where geom.Point, button, clickCount int, mod keys.Modifiers) bool { return true }
+	panel.MouseDragCallback = func(where geom.Point, button int, mod keys.Modifiers) {
+		if !started {
+			started = panel.StartDrag(where, &ux.DragSource{
+				Items:              []map[datatypes.DataType][]byte{{datatypes.PlainText: []byte(payload)}},
+				Operations:         ux.DragOperationCopy,
+				CompletionCallback: func(op ux.DragOperation) { dragDone <- op },
+			})
+		}
+	}
+
+	cmd := exec.Command(os.Args[0], "-target")
+	cmd.Stderr = os.Stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		fail(err.Error())
+	}
+	if err = cmd.Start(); err != nil {
+		fail(err.Error())
+	}
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case strings.HasPrefix(line, "ready "):
+				var to geom.Point
+				if _, err := fmt.Sscanf(line, "ready %f %f", &to.X, &to.Y); err != nil {
+					fail("bad ready line from target: " + line)
+				}
+				ux.Invoke(func() { drag(wnd.ContentRect().Center(), to) })
+			case strings.HasPrefix(line, "dropped "):
+				dropped <- strings.TrimPrefix(line, "dropped ")
+			case line == "ended":
+				ended <- true
+			default:
+				dropped <- line
+			}
+		}
+	}()
+	go func() {
+		deadline := time.After(timeout)
+		var text string
+		select {
+		case text = <-dropped:
+		case <-deadline:
+			fail("timed out waiting for the target to receive the drop")
+		}
+		select {
+		case <-ended:
+		case <-deadline:
+			fail("timed out waiting for the drag to end on the target side")
+		}
+		var op ux.DragOperation
+		select {
+		case op = <-dragDone:
+		case <-deadline:
+			fail("timed out waiting for the drag to complete on the source side")
+		}
+		switch {
+		case text != payload:
+			fail(fmt.Sprintf("target received %q, expected %q", text, payload))
+		case op != ux.DragOperationCopy:
+			fail(fmt.Sprintf("source saw operation %v, expected copy", op))
+		}
+		if err := cmd.Wait(); err != nil {
+			fail("target exited with an error: " + err.Error())
+		}
+		fmt.Println("PASS")
+		os.Exit(0)
+	}()
+}
+
+// drag uses XTEST to press the mouse button at 'from', move to 'to' in steps
+// and release it there.
+func drag(from, to geom.Point) {
+	fake(xproto.MotionNotify, 0, from)
+	fake(xproto.ButtonPress, 1, from)
+	for i := 1; i <= steps; i++ {
+		f := float64(i) / steps
+		pt := geom.Point{X: from.X + (to.X-from.X)*f, Y: from.Y + (to.Y-from.Y)*f}
+		ux.InvokeAfter(func() { fake(xproto.MotionNotify, 0, pt) }, time.Duration(i)*stepDelay)
+	}
+	ux.InvokeAfter(func() { fake(xproto.ButtonRelease, 1, to) }, (steps+5)*stepDelay)
+}
+
+func fake(eventType, detail byte, where geom.Point) {
+	xtest.FakeInput(globals.X11.Conn(), eventType, detail, 0, globals.X11.RootWin(), int16(where.X), int16(where.Y), 0)
+	globals.X11.Sync()
+}
+
+func fail(msg string) {
+	fmt.Fprintln(os.Stderr, "FAIL: "+msg)
+	os.Exit(1)
+}
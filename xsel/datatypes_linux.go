@@ -0,0 +1,84 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package xsel
+
+import (
+	"bytes"
+
+	"github.com/BurntSushi/xgb/xproto"
+	"github.com/richardwilkes/ux/clipboard/datatypes"
+)
+
+// mimeTypes maps the target names commonly used by X11 clients to data
+// types, in order of preference. Names not listed here are looked up with
+// datatypes.ByMime.
+var mimeTypes = []struct {
+	name     string
+	dataType datatypes.DataType
+}{
+	{name: "text/uri-list", dataType: datatypes.FileURL},
+	{name: "UTF8_STRING", dataType: datatypes.PlainText},
+	{name: "text/plain;charset=utf-8", dataType: datatypes.PlainText},
+	{name: "text/plain", dataType: datatypes.PlainText},
+	{name: "image/png", dataType: datatypes.PNG},
+}
+
+// DataTypeForAtom returns the data type for the target, or false if there
+// isn't one.
+func DataTypeForAtom(atom xproto.Atom) (datatypes.DataType, bool) {
+	name := AtomName(atom)
+	for _, one := range mimeTypes {
+		if one.name == name {
+			return one.dataType, true
+		}
+	}
+	if dt, ok := datatypes.ByMime[name]; ok && name != datatypes.Data.Mime {
+		return dt, true
+	}
+	return datatypes.DataType{}, false
+}
+
+// AtomsForDataType returns the targets the data type can be offered as, in
+// order of preference.
+func AtomsForDataType(dataType datatypes.DataType) []xproto.Atom {
+	var atoms []xproto.Atom
+	for _, one := range mimeTypes {
+		if one.dataType.UTI == dataType.UTI {
+			atoms = append(atoms, Atom(one.name))
+		}
+	}
+	if len(atoms) == 0 && dataType.Mime != "" && dataType.Mime != datatypes.Data.Mime {
+		atoms = append(atoms, Atom(dataType.Mime))
+	}
+	return atoms
+}
+
+// SplitURIList breaks a text/uri-list into its URIs, dropping comments and
+// blank lines.
+func SplitURIList(data []byte) [][]byte {
+	var list [][]byte
+	for _, line := range bytes.Split(data, []byte{'\n'}) {
+		line = bytes.TrimSpace(line)
+		if len(line) != 0 && line[0] != '#' {
+			list = append(list, line)
+		}
+	}
+	return list
+}
+
+// JoinURIList combines URIs into a text/uri-list.
+func JoinURIList(list [][]byte) []byte {
+	var buffer bytes.Buffer
+	for _, one := range list {
+		buffer.Write(one)
+		buffer.WriteString("\r\n")
+	}
+	return buffer.Bytes()
+}
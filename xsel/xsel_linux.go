@@ -0,0 +1,490 @@
+// Copyright ©2019-2020 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package xsel
+
+import (
+	"time"
+
+	"github.com/BurntSushi/xgb"
+	"github.com/BurntSushi/xgb/xfixes"
+	"github.com/BurntSushi/xgb/xproto"
+	"github.com/BurntSushi/xgbutil"
+	"github.com/BurntSushi/xgbutil/xevent"
+	"github.com/BurntSushi/xgbutil/xprop"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/log/jot"
+	"github.com/richardwilkes/ux/globals"
+)
+
+// The property used to receive converted selection data.
+const transferProperty = "UX_SELECTION"
+
+// The maximum number of 32-bit units to read from a property at once.
+const maxPropertyLength = 1 << 24
+
+// How often to check for a reply while waiting synchronously.
+const pollInterval = 5 * time.Millisecond
+
+// Provider returns the data for a target, or false if the data cannot be
+// converted to that target.
+type Provider func(target xproto.Atom) (data []byte, ok bool)
+
+type owner struct {
+	window   xproto.Window
+	targets  []xproto.Atom
+	provider Provider
+	lost     func()
+}
+
+type request struct {
+	window    xproto.Window
+	selection xproto.Atom
+	target    xproto.Atom
+	done      func(data []byte, ok bool)
+	incr      bool
+	buffer    []byte
+}
+
+// outgoing tracks data being sent to another client with INCR.
+type outgoing struct {
+	requestor xproto.Window
+	property  xproto.Atom
+	target    xproto.Atom
+	data      []byte
+}
+
+var (
+	owners       = make(map[xproto.Atom]*owner)
+	requests     []*request
+	transfers    []*outgoing
+	attached     = make(map[xproto.Window]bool)
+	changeCounts = make(map[xproto.Atom]int)
+	lastOwners   = make(map[xproto.Atom]xproto.Window)
+	watched      = make(map[xproto.Atom]bool)
+	hooked       bool
+	haveXFixes   bool
+)
+
+// Atom returns the atom for the name, creating it if necessary. Failures
+// are logged and result in xproto.AtomNone.
+func Atom(name string) xproto.Atom {
+	atom, err := xprop.Atm(globals.X11, name)
+	if err != nil {
+		jot.Error(errs.NewWithCause("unable to intern atom "+name, err))
+		return xproto.AtomNone
+	}
+	return atom
+}
+
+// AtomName returns the name of the atom, or an empty string if it cannot be
+// determined.
+func AtomName(atom xproto.Atom) string {
+	name, err := xprop.AtomName(globals.X11, atom)
+	if err != nil {
+		return ""
+	}
+	return name
+}
+
+// Attach connects the event handlers the window needs to own selections and
+// receive requested data. It is safe to call more than once for the same
+// window.
+func Attach(window xproto.Window) {
+	if attached[window] {
+		return
+	}
+	attached[window] = true
+	installHook()
+	// Property changes are needed to receive INCR transfers.
+	conn := globals.X11.Conn()
+	if attrs, err := xproto.GetWindowAttributes(conn, window).Reply(); err == nil {
+		xproto.ChangeWindowAttributes(conn, window, xproto.CwEventMask, []uint32{attrs.YourEventMask | xproto.EventMaskPropertyChange})
+	}
+	xevent.SelectionRequestFun(func(xu *xgbutil.XUtil, e xevent.SelectionRequestEvent) {
+		handleSelectionRequest(e.SelectionRequestEvent)
+	}).Connect(globals.X11, window)
+	xevent.SelectionClearFun(func(xu *xgbutil.XUtil, e xevent.SelectionClearEvent) {
+		if o, ok := owners[e.Selection]; ok && o.window == e.Owner {
+			delete(owners, e.Selection)
+			if o.lost != nil {
+				o.lost()
+			}
+		}
+	}).Connect(globals.X11, window)
+	xevent.SelectionNotifyFun(func(xu *xgbutil.XUtil, e xevent.SelectionNotifyEvent) {
+		handleSelectionNotify(e.SelectionNotifyEvent)
+	}).Connect(globals.X11, window)
+}
+
+// Detach forgets about the window. Any selections it owns are released.
+func Detach(window xproto.Window) {
+	delete(attached, window)
+	for selection, o := range owners {
+		if o.window == window {
+			delete(owners, selection)
+		}
+	}
+	remaining := requests[:0]
+	for _, r := range requests {
+		if r.window == window {
+			r.done(nil, false)
+		} else {
+			remaining = append(remaining, r)
+		}
+	}
+	requests = remaining
+}
+
+// Own claims the selection for the window, which must have been attached.
+// 'targets' lists the atoms the provider can supply data for; requests for
+// the TARGETS atom are answered automatically. 'lost' is called if another
+// client later takes ownership of the selection. Returns false if
+// ownership could not be obtained.
+func Own(window xproto.Window, selection xproto.Atom, targets []xproto.Atom, provider Provider, lost func()) bool {
+	conn := globals.X11.Conn()
+	if err := xproto.SetSelectionOwnerChecked(conn, window, selection, xproto.TimeCurrentTime).Check(); err != nil {
+		jot.Error(errs.NewWithCause("unable to set selection owner", err))
+		return false
+	}
+	reply, err := xproto.GetSelectionOwner(conn, selection).Reply()
+	if err != nil || reply.Owner != window {
+		return false
+	}
+	if previous, ok := owners[selection]; ok && previous.lost != nil && previous.window != window {
+		previous.lost()
+	}
+	owners[selection] = &owner{
+		window:   window,
+		targets:  targets,
+		provider: provider,
+		lost:     lost,
+	}
+	changeCounts[selection]++
+	return true
+}
+
+// Owns returns true if this process currently owns the selection.
+func Owns(selection xproto.Atom) bool {
+	_, ok := owners[selection]
+	return ok
+}
+
+// Disown releases the selection, if this process owns it.
+func Disown(selection xproto.Atom) {
+	if _, ok := owners[selection]; ok {
+		delete(owners, selection)
+		xproto.SetSelectionOwner(globals.X11.Conn(), xproto.WindowNone, selection, xproto.TimeCurrentTime)
+		changeCounts[selection]++
+	}
+}
+
+// ChangeCount returns a number that changes each time the owner of the
+// selection changes. When the XFIXES extension is available, changes are
+// reported by the server. Otherwise, the owner is checked on each call, which
+// can miss a client replacing its own selection.
+func ChangeCount(selection xproto.Atom) int {
+	installHook()
+	if !watched[selection] {
+		watched[selection] = true
+		if haveXFixes {
+			xfixes.SelectSelectionInput(globals.X11.Conn(), globals.X11.RootWin(), selection,
+				xfixes.SelectionEventMaskSetSelectionOwner|xfixes.SelectionEventMaskSelectionWindowDestroy|
+					xfixes.SelectionEventMaskSelectionClientClose)
+		}
+	}
+	if !haveXFixes {
+		if reply, err := xproto.GetSelectionOwner(globals.X11.Conn(), selection).Reply(); err == nil && reply.Owner != lastOwners[selection] {
+			lastOwners[selection] = reply.Owner
+			changeCounts[selection]++
+		}
+	}
+	return changeCounts[selection]
+}
+
+// Request asks the owner of the selection to convert it to the target. Once
+// the data arrives, or the owner refuses, 'done' is called. The window must
+// have been attached.
+func Request(window xproto.Window, selection, target xproto.Atom, time xproto.Timestamp, done func(data []byte, ok bool)) {
+	if o, ok := owners[selection]; ok {
+		// We own it, so skip the round trip through the server.
+		if target == Atom("TARGETS") {
+			done(AtomsToBytes(append([]xproto.Atom{target}, o.targets...)), true)
+		} else {
+			done(o.provider(target))
+		}
+		return
+	}
+	requests = append(requests, &request{
+		window:    window,
+		selection: selection,
+		target:    target,
+		done:      done,
+	})
+	xproto.ConvertSelection(globals.X11.Conn(), window, selection, target, Atom(transferProperty), time)
+}
+
+// RequestAndWait is like Request, but waits up to 'timeout' for the data to
+// arrive rather than calling a function. Events that arrive in the meantime
+// and aren't part of the transfer are queued for the event loop.
+func RequestAndWait(window xproto.Window, selection, target xproto.Atom, timeout time.Duration) (data []byte, ok bool) {
+	finished := false
+	Request(window, selection, target, xproto.TimeCurrentTime, func(d []byte, success bool) {
+		data = d
+		ok = success
+		finished = true
+	})
+	conn := globals.X11.Conn()
+	deadline := time.Now().Add(timeout)
+	for !finished {
+		if time.Now().After(deadline) {
+			for _, r := range requests {
+				if r.window == window && r.selection == selection && r.target == target {
+					removeRequest(r)
+					break
+				}
+			}
+			jot.Warn("timed out waiting for selection " + AtomName(selection))
+			return nil, false
+		}
+		ev, err := conn.PollForEvent()
+		if ev == nil && err == nil {
+			time.Sleep(pollInterval)
+			continue
+		}
+		if err != nil || !dispatch(ev) {
+			xevent.Enqueue(globals.X11, ev, err)
+		}
+	}
+	return data, ok
+}
+
+// CancelRequests abandons the requests the window has made for the selection
+// that haven't completed yet, such as when the owner has stopped responding.
+// Their 'done' functions will not be called.
+func CancelRequests(window xproto.Window, selection xproto.Atom) {
+	for i := len(requests) - 1; i >= 0; i-- {
+		if r := requests[i]; r.window == window && r.selection == selection {
+			removeRequest(r)
+		}
+	}
+}
+
+func removeRequest(r *request) {
+	for i, one := range requests {
+		if one == r {
+			copy(requests[i:], requests[i+1:])
+			requests[len(requests)-1] = nil
+			requests = requests[:len(requests)-1]
+			return
+		}
+	}
+}
+
+// dispatch handles events related to selection transfers while waiting
+// synchronously. Returns false if the event was not handled.
+func dispatch(ev xgb.Event) bool {
+	switch e := ev.(type) {
+	case xproto.SelectionNotifyEvent:
+		if attached[e.Requestor] {
+			handleSelectionNotify(&e)
+			return true
+		}
+	case xproto.SelectionRequestEvent:
+		if o, ok := owners[e.Selection]; ok && o.window == e.Owner {
+			handleSelectionRequest(&e)
+			return true
+		}
+	case xproto.PropertyNotifyEvent:
+		return handlePropertyNotify(&e)
+	case xfixes.SelectionNotifyEvent:
+		changeCounts[e.Selection]++
+		return true
+	}
+	return false
+}
+
+// installHook connects a hook to the event loop that handles the events not
+// tied to a particular window of ours.
+func installHook() {
+	if hooked {
+		return
+	}
+	hooked = true
+	conn := globals.X11.Conn()
+	if err := xfixes.Init(conn); err == nil {
+		if _, err = xfixes.QueryVersion(conn, 2, 0).Reply(); err == nil {
+			haveXFixes = true
+		}
+	}
+	xevent.HookFun(func(xu *xgbutil.XUtil, event interface{}) bool {
+		switch e := event.(type) {
+		case xproto.PropertyNotifyEvent:
+			handlePropertyNotify(&e)
+		case xfixes.SelectionNotifyEvent:
+			changeCounts[e.Selection]++
+			return false
+		}
+		return true
+	}).Connect(globals.X11)
+}
+
+func handleSelectionNotify(e *xproto.SelectionNotifyEvent) {
+	for _, r := range requests {
+		if r.incr || r.window != e.Requestor || r.selection != e.Selection || r.target != e.Target {
+			continue
+		}
+		if e.Property == xproto.AtomNone {
+			removeRequest(r)
+			r.done(nil, false)
+			return
+		}
+		reply, err := xproto.GetProperty(globals.X11.Conn(), true, e.Requestor, e.Property, xproto.GetPropertyTypeAny, 0, maxPropertyLength).Reply()
+		if err != nil {
+			removeRequest(r)
+			jot.Error(errs.NewWithCause("unable to read selection data", err))
+			r.done(nil, false)
+			return
+		}
+		if reply.Type == Atom("INCR") {
+			// Deleting the property, which was done by reading it, tells the
+			// owner to start sending the data in chunks.
+			r.incr = true
+			return
+		}
+		removeRequest(r)
+		r.done(reply.Value, true)
+		return
+	}
+}
+
+// handlePropertyNotify advances any INCR transfers the event applies to.
+// Returns true if it did.
+func handlePropertyNotify(e *xproto.PropertyNotifyEvent) bool {
+	if e.State == xproto.PropertyNewValue && e.Atom == Atom(transferProperty) {
+		for _, r := range requests {
+			if r.incr && r.window == e.Window {
+				receiveChunk(r)
+				return true
+			}
+		}
+	} else if e.State == xproto.PropertyDelete {
+		for _, t := range transfers {
+			if t.requestor == e.Window && t.property == e.Atom {
+				sendChunk(t)
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func receiveChunk(r *request) {
+	reply, err := xproto.GetProperty(globals.X11.Conn(), true, r.window, Atom(transferProperty), xproto.GetPropertyTypeAny, 0, maxPropertyLength).Reply()
+	if err != nil {
+		removeRequest(r)
+		jot.Error(errs.NewWithCause("unable to read selection data", err))
+		r.done(nil, false)
+		return
+	}
+	if len(reply.Value) == 0 {
+		// A zero-length chunk marks the end of the transfer.
+		removeRequest(r)
+		r.done(r.buffer, true)
+		return
+	}
+	r.buffer = append(r.buffer, reply.Value...)
+}
+
+func sendChunk(t *outgoing) {
+	size := chunkSize()
+	if size > len(t.data) {
+		size = len(t.data)
+	}
+	xproto.ChangeProperty(globals.X11.Conn(), xproto.PropModeReplace, t.requestor, t.property, t.target, 8, uint32(size), t.data[:size])
+	if size == 0 {
+		for i, one := range transfers {
+			if one == t {
+				copy(transfers[i:], transfers[i+1:])
+				transfers[len(transfers)-1] = nil
+				transfers = transfers[:len(transfers)-1]
+				break
+			}
+		}
+		return
+	}
+	t.data = t.data[size:]
+}
+
+// chunkSize returns the largest amount of data that can be safely placed in
+// a property with a single request.
+func chunkSize() int {
+	return int(xproto.Setup(globals.X11.Conn()).MaximumRequestLength) * 4 / 2
+}
+
+func handleSelectionRequest(e *xproto.SelectionRequestEvent) {
+	conn := globals.X11.Conn()
+	property := e.Property
+	if property == xproto.AtomNone {
+		// Obsolete clients may not specify a property.
+		property = e.Target
+	}
+	o, ok := owners[e.Selection]
+	if !ok || o.window != e.Owner {
+		property = xproto.AtomNone
+	} else if e.Target == Atom("TARGETS") {
+		atoms := append([]xproto.Atom{e.Target}, o.targets...)
+		xproto.ChangeProperty(conn, xproto.PropModeReplace, e.Requestor, property, xproto.AtomAtom, 32, uint32(len(atoms)), AtomsToBytes(atoms))
+	} else if data, ok := o.provider(e.Target); ok {
+		if len(data) > chunkSize() {
+			// Too large to send at once, so send it in chunks as the
+			// requestor deletes the property.
+			xproto.ChangeWindowAttributes(conn, e.Requestor, xproto.CwEventMask, []uint32{xproto.EventMaskPropertyChange})
+			transfers = append(transfers, &outgoing{
+				requestor: e.Requestor,
+				property:  property,
+				target:    e.Target,
+				data:      data,
+			})
+			size := make([]byte, 4)
+			xgb.Put32(size, uint32(len(data)))
+			xproto.ChangeProperty(conn, xproto.PropModeReplace, e.Requestor, property, Atom("INCR"), 32, 1, size)
+		} else {
+			xproto.ChangeProperty(conn, xproto.PropModeReplace, e.Requestor, property, e.Target, 8, uint32(len(data)), data)
+		}
+	} else {
+		property = xproto.AtomNone
+	}
+	ev := xproto.SelectionNotifyEvent{
+		Time:      e.Time,
+		Requestor: e.Requestor,
+		Selection: e.Selection,
+		Target:    e.Target,
+		Property:  property,
+	}
+	xproto.SendEvent(conn, false, e.Requestor, xproto.EventMaskNoEvent, string(ev.Bytes()))
+}
+
+// Atoms decodes a list of atoms from property data.
+func Atoms(data []byte) []xproto.Atom {
+	atoms := make([]xproto.Atom, 0, len(data)/4)
+	for i := 0; i+4 <= len(data); i += 4 {
+		atoms = append(atoms, xproto.Atom(xgb.Get32(data[i:])))
+	}
+	return atoms
+}
+
+// AtomsToBytes encodes a list of atoms as property data.
+func AtomsToBytes(atoms []xproto.Atom) []byte {
+	data := make([]byte, len(atoms)*4)
+	for i, atom := range atoms {
+		xgb.Put32(data[i*4:], uint32(atom))
+	}
+	return data
+}